@@ -18,5 +18,5 @@ func NewHillClimbing() HillClimbing {
 }
 
 func (a *HillClimbing) Accept(rnd *rand.Rand, best, current, candidate State) (bool, error) {
-	return candidate.Objective() <= current.Objective(), nil
+	return compareStates(candidate, current) <= 0, nil
 }
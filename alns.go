@@ -1,6 +1,7 @@
 package alns
 
 import (
+	"cmp"
 	"math/rand/v2"
 	"time"
 )
@@ -8,11 +9,30 @@ import (
 type Listener func(outcome Outcome, cand State) error
 
 type ALNS struct {
-	Rnd               *rand.Rand
-	CollectObjectives bool
-	Listener          Listener
-	DestroyOperators  []Operator
-	RepairOperators   []Operator
+	Rnd                    *rand.Rand
+	CollectObjectives      bool
+	Listener               Listener
+	DestroyOperators       []Operator
+	RepairOperators        []Operator
+	DestroyOperatorNames   []string         // optional names of the destroy operators used in errors and observers
+	RepairOperatorNames    []string         // optional names of the repair operators used in errors and observers
+	Pool                   *SolutionPool    // optional elite archive of the best distinct solutions
+	Restart                RestartStrategy  // optional restart strategy
+	RestartTarget          RestartTarget    // the solution to restart from
+	Penalty                *AdaptivePenalty // optional penalty of infeasible solutions, see ConstraintViolation
+	Pareto                 *ParetoArchive   // optional archive of non-dominated feasible solutions, see MultiObjective
+	DeltaCheckPeriod       int              // verify the objective deltas every N iterations, see DeltaEvaluated
+	DeltaTolerance         float64          // the allowed difference between the incremental and the full objective, zero means 1e-6
+	DeltaDebug             bool             // verify the objective deltas in every iteration and fail on a mismatch
+	CheckOperators         bool             // fail if an operator mutates the current solution, see Fingerprinter
+	Cache                  *SolutionCache   // optional cache of the recently visited candidates, see Hasher
+	LocalSearches          []LocalSearch    // optional improvers applied to the repaired candidates
+	LocalSearchProbability float64          // the probability to apply the local searches, zero means always
+	LocalSearchGap         float64          // if positive, only candidates within this relative gap to the best are improved
+	Destruction            *Destruction     // optional degree of destruction passed to the sized destroy operators
+	Seeds                  *SeedSequence    // optional independent random streams per component, replaces Rnd
+	Observers              []Observer       // optional observers notified after every iteration, e.g. TraceRecorder
+	random                 *randomStreams
 }
 
 // def iterate(initial_solution, select, accept, stop)
@@ -21,13 +41,20 @@ func (a *ALNS) Iterate(
 	selectOp OperatorSelectionScheme,
 	accept AcceptanceCriterion,
 	stop StoppingCriterion,
-) (*Result, error) {
+) (_ *Result, err error) {
 	if len(a.DestroyOperators) == 0 || len(a.RepairOperators) == 0 {
 		panic("Missing destroy or repair operators.")
 	}
 
 	curr := initSol
 	best := initSol
+	a.random = newRandomStreams(a)
+	rnd := a.random
+
+	if a.Penalty != nil {
+		a.Penalty.start()
+		accept = &penalizedAcceptance{accept: accept, penalty: a.Penalty}
+	}
 
 	numIterations := 0
 	if a.CollectObjectives {
@@ -35,40 +62,88 @@ func (a *ALNS) Iterate(
 			numIterations = maxIterations.MaxIterations + 1
 		}
 	}
-	stats := newStatistics(numIterations, len(a.DestroyOperators), len(a.RepairOperators))
+	stats := newStatistics(numIterations, len(a.DestroyOperators), len(a.RepairOperators), len(a.LocalSearches))
 
 	started := time.Now()
 	if a.CollectObjectives {
 		stats.collectObjective(0, initSol.Objective())
 	}
+	if a.Pool != nil {
+		a.Pool.Add(initSol)
+	}
+	if a.Pareto != nil && isFeasible(initSol) {
+		a.Pareto.Add(initSol)
+	}
+	var fired StoppingCriterion
+	if len(a.Observers) > 0 {
+		defer func() {
+			event := StopEvent{
+				Reason:     stopReason(fired),
+				Iterations: stats.IterationCount,
+				Runtime:    time.Since(started),
+				Best:       best,
+			}
+			if err != nil {
+				event.Reason, event.Err = "error", err
+			}
+			a.notifyStop(&event)
+		}()
+		if err := a.notifyStart(initSol); err != nil {
+			return nil, err
+		}
+	}
 
 	for {
-		if done, err := stop.IsDone(a.Rnd, best, curr); err != nil {
+		if fired, err = firedCriterion(stop, rnd.stopping, best, curr); err != nil {
 			return nil, err
-		} else if done {
+		} else if fired != nil {
 			break
 		}
-		dIdx, rIdx, err := selectOp.Select(a.Rnd, best, curr)
+		dIdx, rIdx, err := selectOp.Select(rnd.selection, best, curr)
 		if err != nil {
 			return nil, err
 		}
-		destroyOp := a.DestroyOperators[dIdx]
-		repairOp := a.RepairOperators[rIdx]
-
-		destroyed, err := destroyOp(curr, a.Rnd)
+		degree := 0.0
+		if a.Destruction != nil {
+			degree = a.Destruction.next(rnd.engine)
+		}
+		cand, err := a.applyOperators(curr, dIdx, rIdx, &stats)
 		if err != nil {
 			return nil, err
 		}
-		cand, err := repairOp(destroyed, a.Rnd)
+		cand, err = a.improve(best, cand, &stats)
 		if err != nil {
 			return nil, err
 		}
 
+		duplicate := false
+		if a.Cache != nil && a.Cache.Visit(cand) {
+			duplicate = true
+			stats.collectDuplicate(dIdx, rIdx)
+		}
+
 		var outcome Outcome
-		best, curr, outcome, err = a.evalCand(accept, best, curr, cand)
+		var accepted bool
+		best, curr, outcome, accepted, err = a.evalCand(accept, best, curr, cand, duplicate && a.Cache.RejectDuplicates)
 		if err != nil {
 			return nil, err
 		}
+		if a.Pool != nil {
+			a.Pool.Add(cand)
+		}
+		feasible := isFeasible(cand)
+		if !feasible {
+			stats.InfeasibleCount++
+		}
+		if a.Pareto != nil && feasible {
+			a.Pareto.Add(cand)
+		}
+		if a.Penalty != nil {
+			a.Penalty.register(feasible)
+		}
+		if a.Destruction != nil {
+			a.Destruction.update(outcome)
+		}
 
 		err = selectOp.Update(cand, dIdx, rIdx, outcome)
 		if err != nil {
@@ -76,10 +151,46 @@ func (a *ALNS) Iterate(
 		}
 
 		stats.IterationCount++
+		if a.Destruction != nil && a.CollectObjectives {
+			stats.collectDestruction(degree, outcome)
+		}
+		if outcome == Best {
+			stats.collectBest(time.Since(started))
+		}
+		if a.Restart != nil {
+			if restart, err := a.Restart.Restart(rnd.engine, best, curr, outcome); err != nil {
+				return nil, err
+			} else if restart {
+				from := curr
+				curr = a.restartState(best)
+				stats.collectRestart(time.Since(started), from.Objective(), curr.Objective())
+			}
+		}
 		if a.CollectObjectives {
 			stats.collectObjective(time.Since(started), curr.Objective())
 		}
 		stats.collectOperators(dIdx, rIdx, outcome)
+
+		if len(a.Observers) > 0 {
+			event := IterationEvent{
+				Iteration: stats.IterationCount,
+				Runtime:   time.Since(started),
+				Destroy:   dIdx,
+				Repair:    rIdx,
+				Candidate: cand,
+				Current:   curr,
+				Best:      best,
+				Outcome:   outcome,
+				Accepted:  accepted,
+				alns:      a,
+				selector:  selectOp,
+			}
+			for _, observer := range a.Observers {
+				if err := observer.OnIteration(&event); err != nil {
+					return nil, err
+				}
+			}
+		}
 	}
 	stats.TotalRuntime = time.Since(started)
 
@@ -87,49 +198,134 @@ func (a *ALNS) Iterate(
 		BestState:  best,
 		Statistics: stats,
 	}
+	if isFeasible(best) {
+		result.BestFeasibleState = best
+	}
+	if a.Pool != nil {
+		result.Pool = a.Pool.States()
+	}
+	if a.Pareto != nil {
+		result.ParetoFront = a.Pareto.States()
+	}
+	if a.Seeds != nil {
+		seed := a.Seeds.Master
+		result.Seed = &seed
+	}
 	return &result, nil
 }
 
-func (a *ALNS) evalCand(accept AcceptanceCriterion, best, curr, cand State) (State, State, Outcome, error) {
-	outcome, err := a.determineOutcome(accept, best, curr, cand)
+func (a *ALNS) applyOperators(curr State, dIdx, rIdx int, stats *Statistics) (State, error) {
+	destroyOp := a.DestroyOperators[dIdx]
+	repairOp := a.RepairOperators[rIdx]
+
+	check := a.deltaCheck(stats)
+
+	var currFingerprint uint64
+	checkMutation := false
+	if a.CheckOperators {
+		currFingerprint, checkMutation = fingerprint(curr)
+	}
+
+	destroyed, err := destroyOp(curr, a.streams().destroy[dIdx])
 	if err != nil {
-		return nil, nil, 0, err
+		return nil, err
+	}
+	if checkMutation {
+		if err := checkFingerprint(curr, currFingerprint, a.destroyOperatorName(dIdx)); err != nil {
+			return nil, err
+		}
+	}
+
+	cand, err := repairOp(destroyed, a.streams().repair[rIdx])
+	if err != nil {
+		return nil, err
+	}
+	if checkMutation {
+		if err := checkFingerprint(curr, currFingerprint, a.repairOperatorName(rIdx)); err != nil {
+			return nil, err
+		}
+	}
+	operators := a.destroyOperatorName(dIdx) + " and " + a.repairOperatorName(rIdx)
+	if err := a.applyDelta(curr, cand, operators, check, stats); err != nil {
+		return nil, err
+	}
+	return cand, nil
+}
+
+func (a *ALNS) restartState(best State) State {
+	if a.RestartTarget == RestartToElite && a.Pool != nil {
+		if state := a.Pool.Random(a.streams().engine); state != nil {
+			return state
+		}
+	}
+	return best
+}
+
+func (a *ALNS) evalCand(accept AcceptanceCriterion, best, curr, cand State, reject bool) (State, State, Outcome, bool, error) {
+	outcome := Reject
+	accepted := false
+	if !reject {
+		var err error
+		outcome, accepted, err = a.determineOutcome(accept, best, curr, cand)
+		if err != nil {
+			return nil, nil, 0, false, err
+		}
 	}
 
 	if a.Listener != nil {
 		if err := a.Listener(outcome, cand); err != nil {
-			return nil, nil, 0, err
+			return nil, nil, 0, false, err
 		}
 	}
 
 	switch outcome {
 	case Best:
-		return cand, cand, outcome, nil
+		return cand, cand, outcome, accepted, nil
 	case Reject:
-		return best, curr, outcome, nil
+		return best, curr, outcome, accepted, nil
 	default:
-		return best, cand, outcome, nil
+		return best, cand, outcome, accepted, nil
 	}
 }
 
-func (a *ALNS) determineOutcome(accept AcceptanceCriterion, best, curr, cand State) (Outcome, error) {
+// determineOutcome returns the outcome of the candidate and the decision of the acceptance criterion,
+// a new best replaces the current solution even if the criterion rejected it.
+func (a *ALNS) determineOutcome(accept AcceptanceCriterion, best, curr, cand State) (Outcome, bool, error) {
 	outcome := Reject
 
-	if accepted, err := accept.Accept(a.Rnd, best, curr, cand); err != nil {
-		return 0, err
-	} else if accepted {
+	accepted, err := accept.Accept(a.streams().acceptance, best, curr, cand)
+	if err != nil {
+		return 0, false, err
+	}
+	if accepted {
 		// accept candidate
 		outcome = Accept
 
-		if cand.Objective() < curr.Objective() {
+		if a.compare(cand, curr) < 0 {
 			outcome = Better
 		}
 	}
 
-	if cand.Objective() < best.Objective() {
+	// an infeasible candidate is never a new best
+	if isFeasible(cand) && (!isFeasible(best) || compareStates(cand, best) < 0) {
 		// candidate is new best
 		outcome = Best
 	}
 
-	return outcome, nil
+	return outcome, accepted, nil
+}
+
+func (a *ALNS) compare(x, y State) int {
+	if a.Penalty != nil {
+		return cmp.Compare(a.Penalty.Objective(x), a.Penalty.Objective(y))
+	}
+	return compareStates(x, y)
+}
+
+// streams returns the random streams of the run, they are created on the first use outside of Iterate.
+func (a *ALNS) streams() *randomStreams {
+	if a.random == nil {
+		a.random = newRandomStreams(a)
+	}
+	return a.random
 }
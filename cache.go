@@ -0,0 +1,49 @@
+package alns
+
+// The `SolutionCache` remembers the hashes (see Hasher) of the recently visited candidates,
+// similar to a tabu list. A candidate whose hash is in the cache is a duplicate.
+type SolutionCache struct {
+	Size             int  // the number of remembered candidates
+	RejectDuplicates bool // reject the duplicates without consulting the acceptance criterion
+	hashes           []uint64
+	counts           map[uint64]int
+	next             int
+}
+
+func NewSolutionCache(size int, rejectDuplicates bool) SolutionCache {
+	return SolutionCache{
+		Size:             size,
+		RejectDuplicates: rejectDuplicates,
+	}
+}
+
+// Visit remembers the state and reports whether it has been visited recently.
+// States that do not implement Hasher are never duplicates.
+func (c *SolutionCache) Visit(state State) bool {
+	h, ok := state.(Hasher)
+	if !ok || c.Size <= 0 {
+		return false
+	}
+	hash := h.Hash()
+
+	if c.counts == nil {
+		c.hashes = make([]uint64, 0, c.Size)
+		c.counts = make(map[uint64]int, c.Size)
+	}
+	duplicate := c.counts[hash] > 0
+
+	if len(c.hashes) < c.Size {
+		c.hashes = append(c.hashes, hash)
+	} else {
+		// forget the oldest candidate
+		oldest := c.hashes[c.next]
+		if c.counts[oldest]--; c.counts[oldest] == 0 {
+			delete(c.counts, oldest)
+		}
+		c.hashes[c.next] = hash
+		c.next = (c.next + 1) % c.Size
+	}
+	c.counts[hash]++
+
+	return duplicate
+}
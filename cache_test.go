@@ -0,0 +1,124 @@
+package alns
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+func TestSolutionCache(t *testing.T) {
+	cache := NewSolutionCache(3, true)
+	hashes := []uint64{1, 2, 1, 3, 4, 1, 2, 2}
+	expected := []bool{false, false, true, false, false, true, false, true}
+	for i, hash := range hashes {
+		got := cache.Visit(&HashedState{hash: hash})
+		if got != expected[i] {
+			t.Errorf("visit %d (hash %d): duplicate %v expected, actual %v", i, hash, expected[i], got)
+		}
+	}
+	if cache.Visit(&FakeState{}) {
+		t.Fatal("a state without hash is never a duplicate")
+	}
+}
+
+func TestAlnsCache(t *testing.T) {
+	solve := func(rejectDuplicates bool) (*Result, int) {
+		opSelect, _ := NewRouletteWheel([4]float64{3, 2, 1, 0.5}, 0.8, 2, 1, nil)
+		accept := AcceptAll{}
+		stop := MaxIterations{MaxIterations: 100}
+		cache := NewSolutionCache(10, rejectDuplicates)
+		accepted := 0
+		a := ALNS{
+			Rnd: rand.New(rand.NewPCG(1, 2)),
+			DestroyOperators: []Operator{
+				func(state State, rnd *rand.Rand) (State, error) {
+					// always produces the same solution
+					return &HashedState{objective: 1, hash: 1}, nil
+				},
+				func(state State, rnd *rand.Rand) (State, error) {
+					return &HashedState{objective: 1, hash: rnd.Uint64()}, nil
+				},
+			},
+			RepairOperators: []Operator{
+				func(state State, rnd *rand.Rand) (State, error) { return state, nil },
+			},
+			Listener: func(outcome Outcome, cand State) error {
+				if outcome != Reject {
+					accepted++
+				}
+				return nil
+			},
+			Cache: &cache,
+		}
+		res, err := a.Iterate(&HashedState{objective: 2, hash: 0}, &opSelect, &accept, &stop)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return res, accepted
+	}
+
+	t.Run("Count", func(t *testing.T) {
+		res, accepted := solve(false)
+		stats := res.Statistics
+		if stats.DestroyDuplicates[0] == 0 || stats.DestroyDuplicates[1] != 0 {
+			t.Fatalf("duplicates are expected only for the first destroy operator, actual %v", stats.DestroyDuplicates)
+		}
+		if stats.RepairDuplicates[0] != stats.DestroyDuplicates[0] {
+			t.Fatalf("%d repair duplicates expected, actual %d", stats.DestroyDuplicates[0], stats.RepairDuplicates[0])
+		}
+		if accepted != 100 {
+			t.Fatalf("100 accepted candidates expected, actual %d", accepted)
+		}
+	})
+
+	t.Run("Reject", func(t *testing.T) {
+		res, accepted := solve(true)
+		duplicates := res.Statistics.DestroyDuplicates[0]
+		if accepted != 100-duplicates {
+			t.Fatalf("%d accepted candidates expected, actual %d", 100-duplicates, accepted)
+		}
+		if res.Statistics.DestroyOperatorCounts[0][Reject] != duplicates {
+			t.Fatalf("%d rejects expected, actual %d", duplicates, res.Statistics.DestroyOperatorCounts[0][Reject])
+		}
+	})
+}
+
+func TestMultiStartCache(t *testing.T) {
+	cache := NewSolutionCache(10, true)
+	a := ALNS{
+		DestroyOperators: []Operator{
+			func(state State, rnd *rand.Rand) (State, error) {
+				return &HashedState{objective: float64(rnd.IntN(5)), hash: rnd.Uint64N(5)}, nil
+			},
+		},
+		RepairOperators: []Operator{
+			func(state State, rnd *rand.Rand) (State, error) { return state, nil },
+		},
+		Cache: &cache,
+	}
+	factory := func(seed uint64) (RunSetup, error) {
+		selector, err := NewRouletteWheel([4]float64{3, 2, 1, 0.5}, 0.8, 1, 1, nil)
+		if err != nil {
+			return RunSetup{}, err
+		}
+		return RunSetup{
+			InitialSolution: &HashedState{objective: 10, hash: 10},
+			Selector:        &selector,
+			Acceptor:        &AcceptAll{},
+			Stop:            &MaxIterations{MaxIterations: 50},
+		}, nil
+	}
+	for _, workers := range []int{1, 2} {
+		res, err := MultiStart(a, factory, []uint64{1, 1}, workers)
+		if err != nil {
+			t.Fatal(err)
+		}
+		first, second := res.Results[0].Statistics, res.Results[1].Statistics
+		if first.DestroyDuplicates[0] != second.DestroyDuplicates[0] || first.DestroyOperatorCounts[0] != second.DestroyOperatorCounts[0] {
+			t.Fatalf("%d workers: the runs of the same seed differ, %d and %d duplicates",
+				workers, first.DestroyDuplicates[0], second.DestroyDuplicates[0])
+		}
+	}
+	if cache.counts != nil {
+		t.Fatal("the cache of the runs expected to be a copy")
+	}
+}
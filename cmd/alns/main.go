@@ -0,0 +1,231 @@
+// Command alns solves benchmark instances with ALNS.
+//
+//	go run ./cmd/alns -instance a280.tsp -iterations 5000 -output result.json -tour a280.tour
+//	go run ./cmd/alns -instance a280.tsp -config run.yaml -seed 7 -trace trace.jsonl
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bibenga/alns"
+	"github.com/bibenga/alns/problems/tsp"
+	"github.com/bibenga/alns/tsplib"
+)
+
+type options struct {
+	instance    string
+	config      string
+	destroy     string
+	repair      string
+	degree      float64
+	localSearch string
+	scores      string
+	decay       float64
+	iterations  int
+	runtime     time.Duration
+	seed        uint64
+	progress    int
+	output      string
+	tour        string
+	trace       string
+}
+
+func main() {
+	var opts options
+	flag.StringVar(&opts.instance, "instance", "", "the TSPLIB instance file (required)")
+	flag.StringVar(&opts.config, "config", "", "the JSON or YAML config file, it replaces the operator, selection and stop flags")
+	flag.StringVar(&opts.destroy, "destroy", "random_removal,path_removal,worst_removal,shaw_removal", "the destroy operators")
+	flag.StringVar(&opts.repair, "repair", "greedy_repair,regret_repair", "the repair operators")
+	flag.Float64Var(&opts.degree, "degree", 0.1, "the degree of destruction")
+	flag.StringVar(&opts.localSearch, "local-search", "", "the local searches applied to the repaired solutions: two_opt, or_opt")
+	flag.StringVar(&opts.scores, "scores", "3,2,1,0.5", "the roulette wheel scores")
+	flag.Float64Var(&opts.decay, "decay", 0.8, "the roulette wheel decay")
+	flag.IntVar(&opts.iterations, "iterations", 1000, "the maximum number of iterations")
+	flag.DurationVar(&opts.runtime, "runtime", 0, "the maximum runtime, e.g. 30s")
+	flag.Uint64Var(&opts.seed, "seed", 1, "the random seed")
+	flag.IntVar(&opts.progress, "progress", 100, "print the progress every N iterations, 0 disables it")
+	flag.StringVar(&opts.output, "output", "-", "the result JSON file, - is stdout")
+	flag.StringVar(&opts.tour, "tour", "", "the TSPLIB tour file of the best solution")
+	flag.StringVar(&opts.trace, "trace", "", "the JSONL file of the decisions of every iteration")
+	flag.Parse()
+
+	if err := run(&opts); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+}
+
+func run(opts *options) error {
+	if opts.instance == "" {
+		return fmt.Errorf("the instance is required")
+	}
+	instance, err := tsplib.ReadFile(opts.instance)
+	if err != nil {
+		return err
+	}
+
+	config, err := loadConfig(opts)
+	if err != nil {
+		return err
+	}
+	components, err := config.Build(operators(opts.degree))
+	if err != nil {
+		return err
+	}
+	localSearches, err := localSearches(opts.localSearch)
+	if err != nil {
+		return err
+	}
+
+	rnd := rand.New(rand.NewPCG(opts.seed, opts.seed))
+	initSol, err := tsp.GreedyRepair(tsp.NewState(tsp.NewProblem(instance.Distances())), rnd)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "instance %s: %d nodes, initial solution %.4f\n",
+		instance.Name, instance.Dimension, initSol.Objective())
+
+	seeds := alns.NewSeedSequence(opts.seed)
+	a := alns.ALNS{
+		Seeds:                &seeds,
+		DestroyOperators:     components.DestroyOperators,
+		RepairOperators:      components.RepairOperators,
+		DestroyOperatorNames: components.DestroyOperatorNames,
+		RepairOperatorNames:  components.RepairOperatorNames,
+		LocalSearches:        localSearches,
+		Listener:             progressListener(opts.progress, os.Stderr, initSol.Objective()),
+	}
+	if opts.trace != "" {
+		f, err := os.Create(opts.trace)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w := bufio.NewWriter(f)
+		defer w.Flush()
+		a.Observers = append(a.Observers, alns.NewTraceRecorder(w))
+	}
+	result, err := a.Iterate(initSol, components.Selector, components.Acceptor, components.Stop)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "best solution %.4f after %d iterations in %s\n",
+		result.BestState.Objective(), result.Statistics.IterationCount, result.Statistics.TotalRuntime)
+
+	best := result.BestState.(*tsp.State)
+	if opts.tour != "" {
+		tour := tsplib.Tour{
+			Name:    instance.Name + ".tour",
+			Comment: fmt.Sprintf("Tour for %s (%.0f)", instance.Name, best.Objective()),
+			Nodes:   best.Tour(),
+		}
+		if err := tsplib.WriteTourFile(opts.tour, &tour); err != nil {
+			return err
+		}
+	}
+
+	return writeResult(opts.output, newOutput(instance, config, opts.seed, result))
+}
+
+func loadConfig(opts *options) (*alns.Config, error) {
+	if opts.config != "" {
+		return alns.LoadConfigFile(opts.config)
+	}
+
+	var scores []float64
+	for _, s := range strings.Split(opts.scores, ",") {
+		score, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid scores: %w", err)
+		}
+		scores = append(scores, score)
+	}
+
+	stop := alns.StopConfig{Type: "max_iterations", MaxIterations: opts.iterations}
+	if opts.runtime > 0 {
+		stop = alns.StopConfig{
+			Type: "any",
+			Criteria: []alns.StopConfig{
+				stop,
+				{Type: "max_runtime", MaxRuntime: opts.runtime.String()},
+			},
+		}
+	}
+
+	return &alns.Config{
+		Destroy: strings.Split(opts.destroy, ","),
+		Repair:  strings.Split(opts.repair, ","),
+		Select:  alns.SelectConfig{Type: "roulette", Scores: scores, Decay: opts.decay},
+		Accept:  alns.AcceptConfig{Type: "hill_climbing"},
+		Stop:    stop,
+	}, nil
+}
+
+func operators(degree float64) alns.OperatorRegistry {
+	return alns.OperatorRegistry{
+		"random_removal": tsp.RandomRemoval(degree),
+		"path_removal":   tsp.PathRemoval(degree),
+		"worst_removal":  tsp.WorstRemoval(degree),
+		"shaw_removal":   tsp.ShawRemoval(degree, 6),
+		"greedy_repair":  tsp.GreedyRepair,
+		"regret_repair":  tsp.RegretRepair,
+	}
+}
+
+func localSearches(names string) ([]alns.LocalSearch, error) {
+	var localSearches []alns.LocalSearch
+	for _, name := range strings.Split(names, ",") {
+		switch strings.TrimSpace(name) {
+		case "":
+		case "two_opt":
+			localSearches = append(localSearches, tsp.TwoOpt)
+		case "or_opt":
+			localSearches = append(localSearches, tsp.OrOpt)
+		default:
+			return nil, fmt.Errorf("unknown local search %q", name)
+		}
+	}
+	return localSearches, nil
+}
+
+func progressListener(every int, w io.Writer, best float64) alns.Listener {
+	if every <= 0 {
+		return nil
+	}
+	iteration := 0
+	return func(outcome alns.Outcome, cand alns.State) error {
+		iteration++
+		if outcome == alns.Best {
+			best = cand.Objective()
+		}
+		if iteration%every == 0 {
+			fmt.Fprintf(w, "iteration %d: best %.4f, candidate %.4f (%s)\n",
+				iteration, best, cand.Objective(), outcome)
+		}
+		return nil
+	}
+}
+
+func writeResult(name string, output *output) error {
+	w := os.Stdout
+	if name != "-" {
+		f, err := os.Create(name)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(output)
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"github.com/bibenga/alns"
+	"github.com/bibenga/alns/problems/tsp"
+	"github.com/bibenga/alns/tsplib"
+)
+
+type output struct {
+	Instance   string           `json:"instance"`
+	Seed       uint64           `json:"seed"`
+	Objective  float64          `json:"objective"`
+	Tour       []int            `json:"tour"`
+	Statistics outputStatistics `json:"statistics"`
+}
+
+type outputStatistics struct {
+	Iterations       int              `json:"iterations"`
+	Runtime          float64          `json:"runtime_seconds"`
+	BestIteration    int              `json:"best_iteration"`
+	BestRuntime      float64          `json:"best_runtime_seconds"`
+	DestroyOperators []outputOperator `json:"destroy_operators"`
+	RepairOperators  []outputOperator `json:"repair_operators"`
+}
+
+type outputOperator struct {
+	Name     string         `json:"name"`
+	Outcomes map[string]int `json:"outcomes"`
+}
+
+func newOutput(instance *tsplib.Instance, config *alns.Config, seed uint64, result *alns.Result) *output {
+	stats := &result.Statistics
+	return &output{
+		Instance:  instance.Name,
+		Seed:      seed,
+		Objective: result.BestState.Objective(),
+		Tour:      result.BestState.(*tsp.State).Tour(),
+		Statistics: outputStatistics{
+			Iterations:       stats.IterationCount,
+			Runtime:          stats.TotalRuntime.Seconds(),
+			BestIteration:    stats.BestIteration,
+			BestRuntime:      stats.BestRuntime.Seconds(),
+			DestroyOperators: newOutputOperators(config.Destroy, stats.DestroyOperatorCounts),
+			RepairOperators:  newOutputOperators(config.Repair, stats.RepairOperatorCounts),
+		},
+	}
+}
+
+func newOutputOperators(names []string, counts []alns.OperatorStatistics) []outputOperator {
+	operators := make([]outputOperator, len(names))
+	for i, name := range names {
+		outcomes := make(map[string]int, len(counts[i]))
+		for outcome, count := range counts[i] {
+			outcomes[alns.Outcome(outcome).String()] = count
+		}
+		operators[i] = outputOperator{Name: name, Outcomes: outcomes}
+	}
+	return operators
+}
@@ -0,0 +1,96 @@
+package alns
+
+import (
+	"cmp"
+	"slices"
+)
+
+// Comparable is an optional extension of State for objectives that cannot be expressed by a single number,
+// e.g. lexicographic objectives. Compare returns a negative number when the state is better than other,
+// a positive number when it is worse and zero when both are equally good.
+type Comparable interface {
+	Compare(other State) int
+}
+
+func compareStates(a, b State) int {
+	if c, ok := a.(Comparable); ok {
+		return c.Compare(b)
+	}
+	return cmp.Compare(a.Objective(), b.Objective())
+}
+
+// CompareLexicographic compares two objective vectors lexicographically.
+// It is a helper for implementing Comparable.
+func CompareLexicographic(a, b []float64) int {
+	return slices.Compare(a, b)
+}
+
+// MultiObjective is an optional extension of State for multi-objective problems (all objectives are minimized).
+type MultiObjective interface {
+	Objectives() []float64
+}
+
+func objectives(state State) []float64 {
+	if m, ok := state.(MultiObjective); ok {
+		return m.Objectives()
+	}
+	return []float64{state.Objective()}
+}
+
+// dominates reports whether a is not worse than b in all objectives and better in at least one.
+func dominates(a, b []float64) bool {
+	better := false
+	for i := range a {
+		if a[i] > b[i] {
+			return false
+		}
+		if a[i] < b[i] {
+			better = true
+		}
+	}
+	return better
+}
+
+// The `ParetoArchive` keeps the non-dominated solutions (see MultiObjective).
+type ParetoArchive struct {
+	states     []State
+	objectives [][]float64
+}
+
+func NewParetoArchive() ParetoArchive {
+	return ParetoArchive{}
+}
+
+// Add adds the state if no solution in the archive dominates it and removes the solutions
+// dominated by the state. It reports whether the state has been added.
+func (p *ParetoArchive) Add(state State) bool {
+	objs := objectives(state)
+	for _, other := range p.objectives {
+		if dominates(other, objs) || slices.Equal(other, objs) {
+			return false
+		}
+	}
+
+	n := 0
+	for i, other := range p.objectives {
+		if !dominates(objs, other) {
+			p.states[n] = p.states[i]
+			p.objectives[n] = other
+			n++
+		}
+	}
+	clear(p.states[n:])
+	p.states = append(p.states[:n], state)
+	p.objectives = append(p.objectives[:n], slices.Clone(objs))
+	return true
+}
+
+// Len returns the number of solutions in the archive.
+func (p *ParetoArchive) Len() int {
+	return len(p.states)
+}
+
+// States returns the non-dominated solutions.
+func (p *ParetoArchive) States() []State {
+	return slices.Clone(p.states)
+}
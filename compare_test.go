@@ -0,0 +1,130 @@
+package alns
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+type LexState struct {
+	tardiness float64
+	travel    float64
+}
+
+func (s LexState) Objective() float64 {
+	return s.tardiness
+}
+
+func (s LexState) Objectives() []float64 {
+	return []float64{s.tardiness, s.travel}
+}
+
+func (s LexState) Compare(other State) int {
+	return CompareLexicographic(s.Objectives(), other.(LexState).Objectives())
+}
+
+func TestComparable(t *testing.T) {
+	t.Run("HillClimbing", func(t *testing.T) {
+		accept := HillClimbing{}
+		best := LexState{1, 10}
+		curr := LexState{1, 10}
+
+		accepted, err := accept.Accept(nil, best, curr, LexState{1, 9})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !accepted {
+			t.Fatal("expected to be accepted")
+		}
+
+		accepted, err = accept.Accept(nil, best, curr, LexState{1, 11})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if accepted {
+			t.Fatal("expected not to be accepted")
+		}
+	})
+
+	t.Run("DetermineOutcome", func(t *testing.T) {
+		a := ALNS{}
+		accept := HillClimbing{}
+		outcome, _, err := a.determineOutcome(&accept, LexState{1, 10}, LexState{2, 0}, LexState{1, 9})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if outcome != Best {
+			t.Fatalf("outcome %s expected, actual %s", Best, outcome)
+		}
+		outcome, _, err = a.determineOutcome(&accept, LexState{1, 10}, LexState{2, 0}, LexState{1, 11})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if outcome != Better {
+			t.Fatalf("outcome %s expected, actual %s", Better, outcome)
+		}
+	})
+
+	t.Run("NoImprovement", func(t *testing.T) {
+		stop := NoImprovement{MaxIterations: 3}
+		best := LexState{1, 10}
+		for i := range 10 {
+			// only the secondary objective is improved
+			best.travel--
+			if done, err := stop.IsDone(nil, best, best); err != nil {
+				t.Fatal(err)
+			} else if done {
+				t.Fatalf("stopped at iteration %d, but the solution is improving", i)
+			}
+		}
+	})
+}
+
+func TestParetoArchive(t *testing.T) {
+	archive := NewParetoArchive()
+	for _, s := range []LexState{{3, 1}, {1, 3}, {2, 2}, {2, 3}, {3, 1}, {1, 1}, {0, 5}} {
+		archive.Add(s)
+	}
+	// {1, 1} dominates {3, 1}, {1, 3} and {2, 2}
+	if archive.Len() != 2 {
+		t.Fatalf("2 solutions expected, actual %d: %v", archive.Len(), archive.States())
+	}
+	for _, s := range archive.States() {
+		if s != (LexState{1, 1}) && s != (LexState{0, 5}) {
+			t.Errorf("unexpected solution %v", s)
+		}
+	}
+}
+
+func TestAlnsPareto(t *testing.T) {
+	opSelect, _ := NewRouletteWheel([4]float64{3, 2, 1, 0.5}, 0.8, 1, 1, nil)
+	accept := AcceptAll{}
+	stop := MaxIterations{MaxIterations: 200}
+	archive := NewParetoArchive()
+	a := ALNS{
+		Rnd: rand.New(rand.NewPCG(1, 2)),
+		DestroyOperators: []Operator{
+			func(state State, rnd *rand.Rand) (State, error) { return state, nil },
+		},
+		RepairOperators: []Operator{
+			func(state State, rnd *rand.Rand) (State, error) {
+				x := rnd.Float64()
+				return LexState{x, (1 - x) * (1 + rnd.Float64())}, nil
+			},
+		},
+		Pareto: &archive,
+	}
+	res, err := a.Iterate(LexState{1, 1}, &opSelect, &accept, &stop)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.ParetoFront) < 2 {
+		t.Fatalf("at least 2 non-dominated solutions expected, actual %d", len(res.ParetoFront))
+	}
+	for i, x := range res.ParetoFront {
+		for j, y := range res.ParetoFront {
+			if i != j && dominates(objectives(x), objectives(y)) {
+				t.Fatalf("%v dominates %v", x, y)
+			}
+		}
+	}
+}
@@ -0,0 +1,306 @@
+package alns
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config is a declarative description of a run, it can be loaded from JSON or YAML, e.g.
+//
+//	{
+//	  "destroy": ["random_removal", "worst_removal"],
+//	  "repair": ["greedy_repair"],
+//	  "select": {"type": "roulette", "scores": [3, 2, 1, 0.5], "decay": 0.8},
+//	  "accept": {"type": "hill_climbing"},
+//	  "stop": {"type": "max_iterations", "max_iterations": 1000}
+//	}
+type Config struct {
+	Destroy []string     `json:"destroy" yaml:"destroy"` // the names of the destroy operators in the registry
+	Repair  []string     `json:"repair" yaml:"repair"`   // the names of the repair operators in the registry
+	Select  SelectConfig `json:"select" yaml:"select"`
+	Accept  AcceptConfig `json:"accept" yaml:"accept"`
+	Stop    StopConfig   `json:"stop" yaml:"stop"`
+}
+
+type SelectConfig struct {
+	Type     string    `json:"type" yaml:"type"` // roulette
+	Scores   []float64 `json:"scores" yaml:"scores"`
+	Decay    float64   `json:"decay" yaml:"decay"`
+	Coupling [][]bool  `json:"coupling,omitempty" yaml:"coupling,omitempty"`
+}
+
+type AcceptConfig struct {
+	Type string `json:"type" yaml:"type"` // hill_climbing
+}
+
+type StopConfig struct {
+	Type          string       `json:"type" yaml:"type"` // max_iterations, max_runtime, no_improvement or any
+	MaxIterations int          `json:"max_iterations,omitempty" yaml:"max_iterations,omitempty"`
+	MaxRuntime    string       `json:"max_runtime,omitempty" yaml:"max_runtime,omitempty"` // e.g. 1m30s
+	Criteria      []StopConfig `json:"criteria,omitempty" yaml:"criteria,omitempty"`       // for the type any
+}
+
+// OperatorRegistry maps the operator names used in a Config to the operators.
+type OperatorRegistry map[string]Operator
+
+// Components are the parts of a run built from a Config.
+type Components struct {
+	DestroyOperators     []Operator
+	RepairOperators      []Operator
+	DestroyOperatorNames []string
+	RepairOperatorNames  []string
+	Selector             OperatorSelectionScheme
+	Acceptor             AcceptanceCriterion
+	Stop                 StoppingCriterion
+}
+
+// ConfigError describes an invalid field of a Config.
+type ConfigError struct {
+	Path string // the path of the field, e.g. select.scores
+	Err  error
+}
+
+func (e *ConfigError) Error() string {
+	if e.Path == "" {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("%s: %s", e.Path, e.Err)
+}
+
+func (e *ConfigError) Unwrap() error {
+	return e.Err
+}
+
+func newConfigError(path string, format string, args ...any) error {
+	return &ConfigError{Path: path, Err: fmt.Errorf(format, args...)}
+}
+
+// wrapConfigError turns an error of a component validation into ConfigError.
+func wrapConfigError(path string, err error) error {
+	var verr *validationError
+	if errors.As(err, &verr) {
+		path = path + "." + verr.field
+	}
+	return &ConfigError{Path: path, Err: err}
+}
+
+// ParseJSONConfig parses a JSON document, unknown fields are errors.
+func ParseJSONConfig(data []byte) (*Config, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	var c Config
+	if err := decoder.Decode(&c); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			return nil, &ConfigError{Path: typeErr.Field, Err: err}
+		}
+		return nil, &ConfigError{Err: err}
+	}
+	return &c, nil
+}
+
+// ParseYAMLConfig parses a YAML document, unknown fields are errors.
+func ParseYAMLConfig(data []byte) (*Config, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	var c Config
+	if err := decoder.Decode(&c); err != nil {
+		var typeErr *yaml.TypeError
+		if errors.As(err, &typeErr) && len(typeErr.Errors) > 0 {
+			var line int
+			if _, scanErr := fmt.Sscanf(typeErr.Errors[0], "line %d:", &line); scanErr == nil {
+				return nil, &ConfigError{Path: yamlPath(data, line), Err: err}
+			}
+		}
+		return nil, &ConfigError{Err: err}
+	}
+	return &c, nil
+}
+
+// yamlPath returns the path of the field at the line of a YAML document, e.g. select.scores or
+// stop.criteria[1].max_runtime, or an empty string if there is no such field.
+func yamlPath(data []byte, line int) string {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return ""
+	}
+	var find func(node *yaml.Node, path string) (string, bool)
+	find = func(node *yaml.Node, path string) (string, bool) {
+		switch node.Kind {
+		case yaml.DocumentNode:
+			for _, child := range node.Content {
+				if p, ok := find(child, path); ok {
+					return p, true
+				}
+			}
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				key, value := node.Content[i], node.Content[i+1]
+				p := key.Value
+				if path != "" {
+					p = path + "." + p
+				}
+				if key.Line == line {
+					return p, true
+				}
+				if p, ok := find(value, p); ok {
+					return p, true
+				}
+			}
+		case yaml.SequenceNode:
+			for i, item := range node.Content {
+				p := fmt.Sprintf("%s[%d]", path, i)
+				if item.Kind != yaml.MappingNode && item.Line == line {
+					return p, true
+				}
+				if p, ok := find(item, p); ok {
+					return p, true
+				}
+			}
+		}
+		return "", false
+	}
+	path, _ := find(&root, "")
+	return path
+}
+
+// LoadConfigFile loads a JSON (.json) or YAML (.yaml, .yml) file.
+func LoadConfigFile(name string) (*Config, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".json":
+		return ParseJSONConfig(data)
+	case ".yaml", ".yml":
+		return ParseYAMLConfig(data)
+	default:
+		return nil, fmt.Errorf("unknown config format of %s", name)
+	}
+}
+
+// Build validates the config and creates the components of the run.
+func (c *Config) Build(registry OperatorRegistry) (*Components, error) {
+	destroyOperators, err := c.operators("destroy", c.Destroy, registry)
+	if err != nil {
+		return nil, err
+	}
+	repairOperators, err := c.operators("repair", c.Repair, registry)
+	if err != nil {
+		return nil, err
+	}
+	selector, err := c.Select.build("select", len(destroyOperators), len(repairOperators))
+	if err != nil {
+		return nil, err
+	}
+	acceptor, err := c.Accept.build("accept")
+	if err != nil {
+		return nil, err
+	}
+	stop, err := c.Stop.build("stop")
+	if err != nil {
+		return nil, err
+	}
+	return &Components{
+		DestroyOperators:     destroyOperators,
+		RepairOperators:      repairOperators,
+		DestroyOperatorNames: slices.Clone(c.Destroy),
+		RepairOperatorNames:  slices.Clone(c.Repair),
+		Selector:             selector,
+		Acceptor:             acceptor,
+		Stop:                 stop,
+	}, nil
+}
+
+func (c *Config) operators(path string, names []string, registry OperatorRegistry) ([]Operator, error) {
+	if len(names) == 0 {
+		return nil, newConfigError(path, "no operators were specified")
+	}
+	operators := make([]Operator, len(names))
+	for i, name := range names {
+		op, ok := registry[name]
+		if !ok {
+			return nil, newConfigError(fmt.Sprintf("%s[%d]", path, i), "unknown operator %q", name)
+		}
+		operators[i] = op
+	}
+	return operators, nil
+}
+
+func (c *SelectConfig) build(path string, numDestroy, numRepair int) (OperatorSelectionScheme, error) {
+	switch c.Type {
+	case "roulette":
+		if len(c.Scores) != 4 {
+			return nil, newConfigError(path+".scores", "4 scores expected, actual %d", len(c.Scores))
+		}
+		selector, err := NewRouletteWheel([4]float64(c.Scores), c.Decay, numDestroy, numRepair, c.Coupling)
+		if err != nil {
+			return nil, wrapConfigError(path, err)
+		}
+		return &selector, nil
+	default:
+		return nil, newConfigError(path+".type", "unknown operator selection scheme %q", c.Type)
+	}
+}
+
+func (c *AcceptConfig) build(path string) (AcceptanceCriterion, error) {
+	switch c.Type {
+	case "hill_climbing":
+		accept := NewHillClimbing()
+		return &accept, nil
+	default:
+		return nil, newConfigError(path+".type", "unknown acceptance criterion %q", c.Type)
+	}
+}
+
+func (c *StopConfig) build(path string) (StoppingCriterion, error) {
+	switch c.Type {
+	case "max_iterations":
+		if c.MaxIterations <= 0 {
+			return nil, newConfigError(path+".max_iterations", "must be positive")
+		}
+		stop := NewMaxIterations(c.MaxIterations)
+		return &stop, nil
+	case "max_runtime":
+		maxRuntime, err := time.ParseDuration(c.MaxRuntime)
+		if err != nil {
+			return nil, &ConfigError{Path: path + ".max_runtime", Err: err}
+		}
+		if maxRuntime <= 0 {
+			return nil, newConfigError(path+".max_runtime", "must be positive")
+		}
+		stop := NewMaxRuntime(maxRuntime)
+		return &stop, nil
+	case "no_improvement":
+		if c.MaxIterations <= 0 {
+			return nil, newConfigError(path+".max_iterations", "must be positive")
+		}
+		stop := NewNoImprovement(c.MaxIterations)
+		return &stop, nil
+	case "any":
+		if len(c.Criteria) == 0 {
+			return nil, newConfigError(path+".criteria", "no criteria were specified")
+		}
+		criterions := make(StoppingCriterions, len(c.Criteria))
+		for i := range c.Criteria {
+			criterion, err := c.Criteria[i].build(fmt.Sprintf("%s.criteria[%d]", path, i))
+			if err != nil {
+				return nil, err
+			}
+			criterions[i] = criterion
+		}
+		return criterions, nil
+	default:
+		return nil, newConfigError(path+".type", "unknown stopping criterion %q", c.Type)
+	}
+}
@@ -0,0 +1,178 @@
+package alns
+
+import (
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestConfig(t *testing.T) {
+	identity := func(state State, rnd *rand.Rand) (State, error) { return state, nil }
+	registry := OperatorRegistry{
+		"random_removal": identity,
+		"worst_removal":  identity,
+		"greedy_repair":  identity,
+	}
+
+	t.Run("JSON", func(t *testing.T) {
+		c, err := ParseJSONConfig([]byte(`{
+			"destroy": ["random_removal", "worst_removal"],
+			"repair": ["greedy_repair"],
+			"select": {"type": "roulette", "scores": [3, 2, 1, 0.5], "decay": 0.8},
+			"accept": {"type": "hill_climbing"},
+			"stop": {"type": "any", "criteria": [
+				{"type": "max_iterations", "max_iterations": 100},
+				{"type": "max_runtime", "max_runtime": "1s"}
+			]}
+		}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		components, err := c.Build(registry)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(components.DestroyOperators) != 2 || len(components.RepairOperators) != 1 {
+			t.Fatalf("2 destroy and 1 repair operators expected, actual %d and %d",
+				len(components.DestroyOperators), len(components.RepairOperators))
+		}
+		selector := components.Selector.(*RouletteWheel)
+		if selector.decay != 0.8 || selector.scores != [4]float64{3, 2, 1, 0.5} {
+			t.Fatalf("unexpected selector %+v", selector)
+		}
+		stop := components.Stop.(StoppingCriterions)
+		if len(stop) != 2 || stop[0].(*MaxIterations).MaxIterations != 100 {
+			t.Fatalf("unexpected stopping criterion %+v", stop)
+		}
+
+		a := ALNS{
+			Rnd:              rand.New(rand.NewPCG(1, 2)),
+			DestroyOperators: components.DestroyOperators,
+			RepairOperators:  components.RepairOperators,
+		}
+		res, err := a.Iterate(&FakeState{objective: 1}, components.Selector, components.Acceptor, components.Stop)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.Statistics.IterationCount != 100 {
+			t.Fatalf("100 iterations expected, actual %d", res.Statistics.IterationCount)
+		}
+	})
+
+	t.Run("YAML", func(t *testing.T) {
+		name := filepath.Join(t.TempDir(), "config.yaml")
+		err := os.WriteFile(name, []byte(`
+destroy: [random_removal]
+repair: [greedy_repair]
+select:
+  type: roulette
+  scores: [3, 2, 1, 0.5]
+  decay: 0.8
+accept:
+  type: hill_climbing
+stop:
+  type: no_improvement
+  max_iterations: 50
+`), 0o600)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c, err := LoadConfigFile(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		components, err := c.Build(registry)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if stop := components.Stop.(*NoImprovement); stop.MaxIterations != 50 {
+			t.Fatalf("50 iterations expected, actual %d", stop.MaxIterations)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		tests := []struct {
+			config string
+			want   string
+		}{
+			{
+				`{"destroy": ["random_removal"], "repair": ["greedy_repair"],
+				  "select": {"type": "roulette", "scores": [-3, 2, 1, 0.5], "decay": 0.8},
+				  "accept": {"type": "hill_climbing"}, "stop": {"type": "max_iterations", "max_iterations": 1}}`,
+				"select.scores: negative scores are not understood",
+			},
+			{
+				`{"destroy": ["random_removal"], "repair": ["greedy_repair"],
+				  "select": {"type": "roulette", "scores": [3, 2, 1, 0.5], "decay": 1.8},
+				  "accept": {"type": "hill_climbing"}, "stop": {"type": "max_iterations", "max_iterations": 1}}`,
+				"select.decay: decay outside [0, 1] not understood",
+			},
+			{
+				`{"destroy": ["random_removal"], "repair": ["greedy_repair"],
+				  "select": {"type": "roulette", "scores": [3, 2, 1, 0.5], "decay": 0.8, "coupling": [[false]]},
+				  "accept": {"type": "hill_climbing"}, "stop": {"type": "max_iterations", "max_iterations": 1}}`,
+				"select.coupling[0]: destroy operator 0 has no coupled repair operators",
+			},
+			{
+				`{"destroy": ["random_removal", "shaw_removal"], "repair": ["greedy_repair"],
+				  "select": {"type": "roulette", "scores": [3, 2, 1, 0.5], "decay": 0.8},
+				  "accept": {"type": "hill_climbing"}, "stop": {"type": "max_iterations", "max_iterations": 1}}`,
+				`destroy[1]: unknown operator "shaw_removal"`,
+			},
+			{
+				`{"destroy": ["random_removal"], "repair": ["greedy_repair"],
+				  "select": {"type": "roulette", "scores": [3, 2, 1], "decay": 0.8},
+				  "accept": {"type": "hill_climbing"}, "stop": {"type": "max_iterations", "max_iterations": 1}}`,
+				"select.scores: 4 scores expected, actual 3",
+			},
+			{
+				`{"destroy": ["random_removal"], "repair": ["greedy_repair"],
+				  "select": {"type": "roulette", "scores": [3, 2, 1, 0.5], "decay": 0.8},
+				  "accept": {"type": "hill_climbing"}, "stop": {"type": "any", "criteria": [
+				    {"type": "max_iterations", "max_iterations": 1},
+				    {"type": "max_runtime", "max_runtime": "-1s"}
+				  ]}}`,
+				"stop.criteria[1].max_runtime: must be positive",
+			},
+			{
+				`{"destroy": ["random_removal"], "repair": ["greedy_repair"],
+				  "select": {"type": "roulette", "scores": [3, 2, 1, 0.5], "decay": 0.8},
+				  "accept": {"type": "annealing"}, "stop": {"type": "max_iterations", "max_iterations": 1}}`,
+				`accept.type: unknown acceptance criterion "annealing"`,
+			},
+		}
+		for i, tt := range tests {
+			c, err := ParseJSONConfig([]byte(tt.config))
+			if err != nil {
+				t.Fatalf("config %d: %s", i, err)
+			}
+			_, err = c.Build(registry)
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("config %d: error %q expected, actual %v", i, tt.want, err)
+			}
+		}
+
+		_, err := ParseJSONConfig([]byte(`{"select": {"scores": "3, 2, 1"}}`))
+		if configErr, ok := err.(*ConfigError); !ok || configErr.Path != "select.scores" {
+			t.Fatalf("the error of select.scores expected, actual %v", err)
+		}
+		_, err = ParseJSONConfig([]byte(`{"selector": {}}`))
+		if err == nil {
+			t.Fatal("unknown field error expected")
+		}
+
+		for config, path := range map[string]string{
+			"select:\n  type: roulette\n  decay: high\n":                               "select.decay",
+			"select:\n  scores: [3, 2, 1, 0.5]\nstop:\n  max_iterations: many\n":       "stop.max_iterations",
+			"stop:\n  criteria:\n    - type: max_runtime\n      max_iterations: [1]\n": "stop.criteria[0].max_iterations",
+			"destroy:\n  - random_removal\n  - [shaw_removal]\n":                       "destroy[1]",
+			"stop:\n  type: any\n  limit: 1\n":                                         "stop.limit",
+		} {
+			_, err := ParseYAMLConfig([]byte(config))
+			if configErr, ok := err.(*ConfigError); !ok || configErr.Path != path {
+				t.Errorf("the error of %s expected, actual %v", path, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,284 @@
+// Package dashboard serves a live view of a running ALNS: an embedded HTML page with the objective curve,
+// the operator weights and the outcome counts, fed by a stream of server-sent events.
+//
+//	ctx, cancel := context.WithCancel(context.Background())
+//	d := dashboard.New(cancel)
+//	a.Observers = append(a.Observers, d)
+//	go http.ListenAndServe("localhost:8080", d)
+//	stop := alns.NewContext(ctx)
+//	result, err := a.Iterate(initSol, &sel, &accept, &stop)
+package dashboard
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	_ "embed"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/bibenga/alns"
+)
+
+//go:embed index.html
+var indexHTML []byte
+
+// TokenHeader is the request header with the token of the dashboard required by "/stop".
+const TokenHeader = "X-Dashboard-Token"
+
+// MaxHistory is the maximum number of the points of the objective curve, older points are thinned out.
+const MaxHistory = 5000
+
+// The `Dashboard` is an alns.RunObserver and an http.Handler serving the page at "/", the event stream
+// at "/events" and the stop button at "/stop". The progress is published at most once per Interval and
+// on every new best solution, slow clients miss intermediate updates instead of slowing down the run.
+// The stop requires the random token of the dashboard in the TokenHeader, the token is embedded in
+// the page, so other sites cannot stop the run by a cross-origin request.
+type Dashboard struct {
+	Interval time.Duration
+
+	token     string
+	cancel    context.CancelFunc
+	mux       *http.ServeMux
+	mu        sync.Mutex
+	progress  progress
+	history   []point
+	published time.Time
+	clients   map[chan []byte]struct{}
+}
+
+var _ alns.RunObserver = &Dashboard{}
+var _ http.Handler = &Dashboard{}
+
+// point is a point of the objective curve.
+type point struct {
+	Iteration int     `json:"iteration"`
+	Best      float64 `json:"best"`
+	Current   float64 `json:"current"`
+}
+
+type operator struct {
+	Name     string                  `json:"name"`
+	Weight   *float64                `json:"weight,omitempty"`
+	Outcomes alns.OperatorStatistics `json:"outcomes"` // see alns.Statistics
+}
+
+type progress struct {
+	point
+	Elapsed float64    `json:"elapsed"` // seconds
+	Running bool       `json:"running"`
+	Reason  string     `json:"reason,omitempty"`
+	Error   string     `json:"error,omitempty"`
+	Destroy []operator `json:"destroy"`
+	Repair  []operator `json:"repair"`
+}
+
+// New creates the dashboard, the stop button calls cancel (usually the cancel function of the context of
+// the alns.Context stopping criterion); a nil cancel disables the button.
+func New(cancel context.CancelFunc) *Dashboard {
+	d := &Dashboard{
+		Interval: 250 * time.Millisecond,
+		token:    newToken(),
+		cancel:   cancel,
+		mux:      http.NewServeMux(),
+		clients:  make(map[chan []byte]struct{}),
+	}
+	d.mux.HandleFunc("GET /{$}", d.handleIndex)
+	d.mux.HandleFunc("GET /events", d.handleEvents)
+	d.mux.HandleFunc("POST /stop", d.handleStop)
+	return d
+}
+
+func newToken() string {
+	token := make([]byte, 16)
+	_, _ = rand.Read(token) // never returns an error
+	return hex.EncodeToString(token)
+}
+
+// Token returns the token required by "/stop", e.g. for stopping the run by a script.
+func (d *Dashboard) Token() string {
+	return d.token
+}
+
+func (d *Dashboard) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	d.mux.ServeHTTP(w, r)
+}
+
+func (d *Dashboard) OnStart(event *alns.StartEvent) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	objective := event.Initial.Objective()
+	d.progress = progress{
+		point:   point{Iteration: 0, Best: objective, Current: objective},
+		Running: true,
+		Destroy: operators(event.DestroyOperators),
+		Repair:  operators(event.RepairOperators),
+	}
+	d.history = []point{d.progress.point}
+	d.publish()
+	return nil
+}
+
+func operators(names []string) []operator {
+	operators := make([]operator, len(names))
+	for i, name := range names {
+		operators[i].Name = name
+	}
+	return operators
+}
+
+func (d *Dashboard) OnIteration(event *alns.IterationEvent) error {
+	best, current := event.Best.Objective(), event.Current.Objective()
+	destroyWeights, repairWeights := event.Weights()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	p := &d.progress
+	p.point = point{Iteration: event.Iteration, Best: best, Current: current}
+	p.Elapsed = event.Runtime.Seconds()
+	if event.Destroy < len(p.Destroy) {
+		p.Destroy[event.Destroy].Outcomes[event.Outcome]++
+	}
+	if event.Repair < len(p.Repair) {
+		p.Repair[event.Repair].Outcomes[event.Outcome]++
+	}
+	setWeights(p.Destroy, destroyWeights)
+	setWeights(p.Repair, repairWeights)
+
+	if event.Outcome == alns.Best || time.Since(d.published) >= d.Interval {
+		d.record()
+		d.publish()
+	}
+	return nil
+}
+
+func setWeights(operators []operator, weights []float64) {
+	for i := range min(len(operators), len(weights)) {
+		operators[i].Weight = &weights[i]
+	}
+}
+
+func (d *Dashboard) OnStop(event *alns.StopEvent) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	p := &d.progress
+	p.Running = false
+	p.Reason = event.Reason
+	p.Elapsed = event.Runtime.Seconds()
+	if event.Err != nil {
+		p.Error = event.Err.Error()
+	}
+	d.record()
+	d.publish()
+}
+
+// record adds the current progress to the objective curve.
+func (d *Dashboard) record() {
+	if last := d.history[len(d.history)-1]; last == d.progress.point {
+		return
+	}
+	if len(d.history) >= MaxHistory {
+		// keep every other point, the first and the last points are kept
+		thinned := d.history[:0]
+		for i, p := range d.history {
+			if i%2 == 0 || i == len(d.history)-1 {
+				thinned = append(thinned, p)
+			}
+		}
+		d.history = thinned
+	}
+	d.history = append(d.history, d.progress.point)
+}
+
+// publish sends the progress to the clients, the clients with full buffers miss the oldest progress
+// so that the last one is always delivered.
+func (d *Dashboard) publish() {
+	d.published = time.Now()
+	if len(d.clients) == 0 {
+		return
+	}
+	data, err := json.Marshal(d.progress)
+	if err != nil {
+		return // e.g. an infinite objective
+	}
+	for client := range d.clients {
+		select {
+		case client <- data:
+		default:
+			select {
+			case <-client:
+			default:
+			}
+			client <- data // only publish sends, there is a free slot
+		}
+	}
+}
+
+func (d *Dashboard) handleIndex(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = w.Write(bytes.Replace(indexHTML, []byte("{{token}}"), []byte(d.token), 1))
+}
+
+// handleEvents streams the objective curve as a "history" event followed by the "progress" events.
+func (d *Dashboard) handleEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+	client := make(chan []byte, 16)
+
+	d.mu.Lock()
+	history, err := json.Marshal(d.history)
+	if err == nil && d.history != nil {
+		var progress []byte
+		if progress, err = json.Marshal(d.progress); err == nil {
+			client <- progress
+		}
+	}
+	d.clients[client] = struct{}{}
+	d.mu.Unlock()
+	defer func() {
+		d.mu.Lock()
+		delete(d.clients, client)
+		d.mu.Unlock()
+	}()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	fmt.Fprintf(w, "event: history\ndata: %s\n\n", history)
+	flusher.Flush()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case data := <-client:
+			if _, err := fmt.Fprintf(w, "event: progress\ndata: %s\n\n", data); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+func (d *Dashboard) handleStop(w http.ResponseWriter, r *http.Request) {
+	if subtle.ConstantTimeCompare([]byte(r.Header.Get(TokenHeader)), []byte(d.token)) != 1 {
+		http.Error(w, "invalid token", http.StatusForbidden)
+		return
+	}
+	if d.cancel == nil {
+		http.Error(w, "stopping is disabled", http.StatusNotImplemented)
+		return
+	}
+	d.cancel()
+	w.WriteHeader(http.StatusAccepted)
+}
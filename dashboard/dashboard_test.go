@@ -0,0 +1,226 @@
+package dashboard
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bibenga/alns"
+)
+
+type objectiveState float64
+
+func (s objectiveState) Objective() float64 {
+	return float64(s)
+}
+
+func newALNS(d *Dashboard) alns.ALNS {
+	seeds := alns.NewSeedSequence(1)
+	return alns.ALNS{
+		Seeds: &seeds,
+		DestroyOperators: []alns.Operator{
+			func(state alns.State, rnd *rand.Rand) (alns.State, error) { return state, nil },
+		},
+		RepairOperators: []alns.Operator{
+			func(state alns.State, rnd *rand.Rand) (alns.State, error) { return objectiveState(rnd.Float64()), nil },
+			func(state alns.State, rnd *rand.Rand) (alns.State, error) { return objectiveState(rnd.Float64()), nil },
+		},
+		RepairOperatorNames: []string{"first", "second"},
+		Observers:           []alns.Observer{d},
+	}
+}
+
+// sseEvent is an event of the stream.
+type sseEvent struct {
+	name string
+	data string
+}
+
+func readEvents(body io.Reader) <-chan sseEvent {
+	events := make(chan sseEvent)
+	go func() {
+		defer close(events)
+		scanner := bufio.NewScanner(body)
+		scanner.Buffer(nil, 1<<20)
+		var event sseEvent
+		for scanner.Scan() {
+			line := scanner.Text()
+			switch {
+			case strings.HasPrefix(line, "event: "):
+				event.name = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				event.data = strings.TrimPrefix(line, "data: ")
+			case line == "":
+				events <- event
+				event = sseEvent{}
+			}
+		}
+	}()
+	return events
+}
+
+// postStop posts the stop request with the token.
+func postStop(t *testing.T, url, token string) int {
+	request, err := http.NewRequest(http.MethodPost, url+"/stop", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != "" {
+		request.Header.Set(TokenHeader, token)
+	}
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	response.Body.Close()
+	return response.StatusCode
+}
+
+func TestDashboardIndex(t *testing.T) {
+	d := New(nil)
+	server := httptest.NewServer(d)
+	defer server.Close()
+
+	response, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(response.Body)
+	response.Body.Close()
+	if response.StatusCode != http.StatusOK || !strings.Contains(string(body), `new EventSource("events")`) {
+		t.Fatalf("the page expected, actual %d %s", response.StatusCode, body)
+	}
+	if len(d.Token()) != 32 || !strings.Contains(string(body), `content="`+d.Token()+`"`) {
+		t.Fatalf("the token %q expected in the page", d.Token())
+	}
+	if other := New(nil); other.Token() == d.Token() {
+		t.Fatal("a token per dashboard expected")
+	}
+
+	if status := postStop(t, server.URL, ""); status != http.StatusForbidden {
+		t.Fatalf("the stop without the token expected to be forbidden, actual %d", status)
+	}
+	if status := postStop(t, server.URL, d.Token()); status != http.StatusNotImplemented {
+		t.Fatalf("the stop without cancel expected to be disabled, actual %d", status)
+	}
+}
+
+func TestDashboardStop(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	d := New(cancel)
+	d.Interval = 0
+	server := httptest.NewServer(d)
+	defer server.Close()
+
+	response, err := http.Get(server.URL + "/events")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+	if contentType := response.Header.Get("Content-Type"); contentType != "text/event-stream" {
+		t.Fatalf("the event stream expected, actual %s", contentType)
+	}
+	events := readEvents(response.Body)
+	if event := <-events; event.name != "history" || event.data != "null" {
+		t.Fatalf("the empty history expected, actual %+v", event)
+	}
+
+	a := newALNS(d)
+	results := make(chan *alns.Result)
+	go func() {
+		selector, _ := alns.NewRouletteWheel([4]float64{3, 2, 1, 0.5}, 0.8, 1, 2, nil)
+		stop := alns.NewStoppingCriterions(
+			&alns.Context{Context: ctx},
+			&alns.MaxIterations{MaxIterations: math.MaxInt - 1},
+		)
+		result, err := a.Iterate(objectiveState(1), &selector, &alns.HillClimbing{}, stop)
+		if err != nil {
+			t.Error(err)
+		}
+		results <- result
+	}()
+
+	// stop the run after a few progress events
+	var last progress
+	for i := 0; ; i++ {
+		event, ok := <-events
+		if !ok {
+			t.Fatal("the stream ended")
+		}
+		if event.name != "progress" {
+			t.Fatalf("a progress event expected, actual %+v", event)
+		}
+		last = progress{}
+		if err := json.Unmarshal([]byte(event.data), &last); err != nil {
+			t.Fatal(err)
+		}
+		if i == 3 {
+			if status := postStop(t, server.URL, "wrong"); status != http.StatusForbidden {
+				t.Fatalf("the stop with a wrong token expected to be forbidden, actual %d", status)
+			}
+			if status := postStop(t, server.URL, d.Token()); status != http.StatusAccepted {
+				t.Fatalf("the stop expected to be accepted, actual %d", status)
+			}
+		}
+		if !last.Running {
+			break
+		}
+	}
+	result := <-results
+
+	if last.Reason != "Context" || last.Iteration != result.Statistics.IterationCount {
+		t.Fatalf("the stop after %d iterations expected, actual %+v", result.Statistics.IterationCount, last)
+	}
+	if last.Best != result.BestState.Objective() {
+		t.Fatalf("the best %f expected, actual %f", result.BestState.Objective(), last.Best)
+	}
+	for i, op := range last.Repair {
+		if op.Outcomes != result.Statistics.RepairOperatorCounts[i] || op.Weight == nil {
+			t.Fatalf("the outcomes %v and the weight of %s expected, actual %+v",
+				result.Statistics.RepairOperatorCounts[i], op.Name, op)
+		}
+	}
+	if last.Repair[1].Name != "second" || last.Destroy[0].Name != "destroy operator 0" {
+		t.Fatalf("the operator names expected, actual %+v %+v", last.Destroy, last.Repair)
+	}
+
+	// a new client gets the objective curve of the run
+	response, err = http.Get(server.URL + "/events")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+	var history []point
+	if event := <-readEvents(response.Body); event.name != "history" {
+		t.Fatalf("the history expected, actual %+v", event)
+	} else if err := json.Unmarshal([]byte(event.data), &history); err != nil {
+		t.Fatal(err)
+	}
+	if history[0].Iteration != 0 || history[len(history)-1] != last.point {
+		t.Fatalf("the curve from the start to the stop expected, actual %v", history)
+	}
+}
+
+func TestDashboardHistory(t *testing.T) {
+	d := New(nil)
+	d.Interval = 0
+	a := newALNS(d)
+	selector, _ := alns.NewRouletteWheel([4]float64{3, 2, 1, 0.5}, 0.8, 1, 2, nil)
+	if _, err := a.Iterate(objectiveState(1), &selector, &alns.HillClimbing{}, &alns.MaxIterations{MaxIterations: 3 * MaxHistory}); err != nil {
+		t.Fatal(err)
+	}
+	if len(d.history) > MaxHistory {
+		t.Fatalf("at most %d points expected, actual %d", MaxHistory, len(d.history))
+	}
+	if d.history[0].Iteration != 0 || d.history[len(d.history)-1].Iteration != 3*MaxHistory {
+		t.Fatal("the first and the last points of the curve expected")
+	}
+}
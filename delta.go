@@ -0,0 +1,66 @@
+package alns
+
+import (
+	"fmt"
+	"math"
+)
+
+// DeltaEvaluated is an optional extension of State for incremental evaluation of expensive objectives.
+//
+// The state accumulates the change of the objective (ObjectiveDelta) made by the operators since its
+// objective was set, a clone keeps the delta of the original. The engine sets the objective of every
+// repaired candidate from the objective of the current solution and the delta accumulated by the
+// destroy and the repair operator, the objective of a destroyed state is not used. EvaluateObjective
+// computes the objective from scratch, it is used to verify the deltas (see ALNS.DeltaCheckPeriod and
+// ALNS.DeltaDebug). The delta of the initial solution must be zero.
+type DeltaEvaluated interface {
+	ObjectiveDelta() float64
+	// SetObjective sets the objective computed by the engine and resets the delta to zero.
+	SetObjective(objective float64)
+	EvaluateObjective() float64
+}
+
+const defaultDeltaTolerance = 1e-6 // see ALNS.DeltaTolerance
+
+// DeltaMismatchError is returned in the debug mode when the objective computed from a delta differs
+// from the full recomputation.
+type DeltaMismatchError struct {
+	Operator    string  // the operators that produced the state
+	Incremental float64 // the objective computed from the delta
+	Full        float64 // the objective computed from scratch
+}
+
+func (e *DeltaMismatchError) Error() string {
+	return fmt.Sprintf("%s: objective delta mismatch: incremental %f, full %f", e.Operator, e.Incremental, e.Full)
+}
+
+// deltaCheck reports whether the deltas of the current iteration are verified.
+func (a *ALNS) deltaCheck(stats *Statistics) bool {
+	return a.DeltaCheckPeriod > 0 && (stats.IterationCount+1)%a.DeltaCheckPeriod == 0
+}
+
+// applyDelta sets the objective of the state produced by the operators from the objective of their input.
+func (a *ALNS) applyDelta(input, output State, operator string, check bool, stats *Statistics) error {
+	d, ok := output.(DeltaEvaluated)
+	if !ok {
+		return nil
+	}
+
+	objective := input.Objective() + d.ObjectiveDelta()
+	if check || a.DeltaDebug {
+		tolerance := a.DeltaTolerance
+		if tolerance == 0 {
+			tolerance = defaultDeltaTolerance
+		}
+		full := d.EvaluateObjective()
+		if math.Abs(full-objective) > tolerance {
+			if a.DeltaDebug {
+				return &DeltaMismatchError{Operator: operator, Incremental: objective, Full: full}
+			}
+			stats.collectDeltaMismatch(operator)
+			objective = full
+		}
+	}
+	d.SetObjective(objective)
+	return nil
+}
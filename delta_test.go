@@ -0,0 +1,164 @@
+package alns
+
+import (
+	"errors"
+	"math"
+	"math/rand/v2"
+	"testing"
+)
+
+type DeltaState struct {
+	values      []float64
+	delta       float64
+	objective   float64
+	evaluations int
+}
+
+func (s *DeltaState) Objective() float64 {
+	return s.objective
+}
+
+func (s *DeltaState) ObjectiveDelta() float64 {
+	return s.delta
+}
+
+func (s *DeltaState) SetObjective(objective float64) {
+	s.objective = objective
+	s.delta = 0
+}
+
+func (s *DeltaState) EvaluateObjective() float64 {
+	s.evaluations++
+	return sum(s.values)
+}
+
+func TestAlnsDelta(t *testing.T) {
+	solve := func(a ALNS, wrongDelta bool) (*Result, error) {
+		opSelect, _ := NewRouletteWheel([4]float64{3, 2, 1, 0.5}, 0.8, 1, 1, nil)
+		accept := AcceptAll{}
+		stop := MaxIterations{MaxIterations: 100}
+		a.Rnd = rand.New(rand.NewPCG(1, 2))
+		if a.DestroyOperators == nil {
+			a.DestroyOperators = []Operator{
+				func(state State, rnd *rand.Rand) (State, error) {
+					curr := state.(*DeltaState)
+					idx := rnd.IntN(len(curr.values))
+					destroyed := &DeltaState{values: append([]float64{}, curr.values...)}
+					destroyed.delta = -destroyed.values[idx]
+					destroyed.values[idx] = 0
+					return destroyed, nil
+				},
+			}
+		}
+		a.RepairOperators = []Operator{
+			func(state State, rnd *rand.Rand) (State, error) {
+				destroyed := state.(*DeltaState)
+				for i, v := range destroyed.values {
+					if v == 0 {
+						destroyed.values[i] = rnd.Float64()
+						destroyed.delta += destroyed.values[i]
+					}
+				}
+				if wrongDelta {
+					destroyed.delta += 1
+				}
+				return destroyed, nil
+			},
+		}
+		return a.Iterate(&DeltaState{values: []float64{1, 1, 1}, objective: 3}, &opSelect, &accept, &stop)
+	}
+
+	t.Run("Trusted", func(t *testing.T) {
+		// the default tolerance absorbs the rounding errors
+		res, err := solve(ALNS{DeltaCheckPeriod: 10}, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		best := res.BestState.(*DeltaState)
+		if math.Abs(best.Objective()-sum(best.values)) > 1e-9 {
+			t.Fatalf("objective %f expected, actual %f", sum(best.values), best.Objective())
+		}
+		if res.Statistics.DeltaMismatches != 0 {
+			t.Fatalf("no mismatches expected, actual %d", res.Statistics.DeltaMismatches)
+		}
+	})
+
+	t.Run("Corrected", func(t *testing.T) {
+		res, err := solve(ALNS{DeltaTolerance: 1e-9, DeltaCheckPeriod: 10}, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// every 10th candidate is corrected
+		if res.Statistics.DeltaMismatches != 10 {
+			t.Fatalf("10 mismatches expected, actual %d", res.Statistics.DeltaMismatches)
+		}
+		if n := res.Statistics.DeltaMismatchCounts["destroy operator 0 and repair operator 0"]; n != 10 {
+			t.Fatalf("10 mismatches of the operators expected, actual %v", res.Statistics.DeltaMismatchCounts)
+		}
+	})
+
+	t.Run("Debug", func(t *testing.T) {
+		_, err := solve(ALNS{DeltaTolerance: 1e-9, DeltaDebug: true}, true)
+		var mismatch *DeltaMismatchError
+		if !errors.As(err, &mismatch) {
+			t.Fatalf("DeltaMismatchError expected, actual %v", err)
+		}
+		if mismatch.Operator != "destroy operator 0 and repair operator 0" {
+			t.Fatalf("destroy operator 0 and repair operator 0 expected, actual %s", mismatch.Operator)
+		}
+	})
+
+	t.Run("Clone", func(t *testing.T) {
+		// the copy of the current solution has no delta, its objective was set by the engine
+		copyRemoval := func(state State, rnd *rand.Rand) (State, error) {
+			destroyed := *state.(*DeltaState)
+			destroyed.values = append([]float64{}, destroyed.values...)
+			destroyed.delta -= destroyed.values[0]
+			destroyed.values[0] = 0
+			return &destroyed, nil
+		}
+		_, err := solve(ALNS{DeltaDebug: true, DestroyOperators: []Operator{copyRemoval}}, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("LocalSearch", func(t *testing.T) {
+		// the candidate returned as is must not get the delta of the repair operator again
+		identity := func(state State, rnd *rand.Rand) (State, error) { return state, nil }
+		_, err := solve(ALNS{DeltaTolerance: 1e-9, DeltaDebug: true, LocalSearches: []LocalSearch{identity}}, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// the candidate improved in place gets the delta of the local search
+		halve := func(state State, rnd *rand.Rand) (State, error) {
+			cand := state.(*DeltaState)
+			cand.delta -= cand.values[0] / 2
+			cand.values[0] /= 2
+			return cand, nil
+		}
+		res, err := solve(ALNS{DeltaTolerance: 1e-9, DeltaDebug: true, LocalSearches: []LocalSearch{halve}}, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if best := res.BestState.(*DeltaState); math.Abs(best.Objective()-sum(best.values)) > 1e-9 {
+			t.Fatalf("objective %f expected, actual %f", sum(best.values), best.Objective())
+		}
+
+		// the objective of the candidate improved in place without the delta is corrected
+		forgetful := func(state State, rnd *rand.Rand) (State, error) {
+			cand := state.(*DeltaState)
+			cand.values[0] /= 2
+			return cand, nil
+		}
+		res, err = solve(ALNS{DeltaTolerance: 1e-9, LocalSearches: []LocalSearch{forgetful}}, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if best := res.BestState.(*DeltaState); res.Statistics.DeltaMismatches == 0 ||
+			math.Abs(best.Objective()-sum(best.values)) > 1e-9 {
+			t.Fatalf("the corrected objective %f expected, actual %f", sum(best.values), best.Objective())
+		}
+	})
+}
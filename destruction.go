@@ -0,0 +1,171 @@
+package alns
+
+import (
+	"math/rand/v2"
+)
+
+// DegreeOfDestruction chooses the fraction of the solution that the destroy operators remove.
+type DegreeOfDestruction interface {
+	// Degree returns the degree of destruction for the next iteration, in (0, 1].
+	Degree(rnd *rand.Rand) float64
+	// Update is called with the outcome of the iteration.
+	Update(outcome Outcome)
+}
+
+// The `FixedDegree` always returns the same degree of destruction.
+type FixedDegree struct {
+	Value float64
+}
+
+func NewFixedDegree(value float64) (FixedDegree, error) {
+	if !(0 < value && value <= 1) {
+		return FixedDegree{}, newValidationError("value", "degree outside (0, 1] not understood")
+	}
+	return FixedDegree{Value: value}, nil
+}
+
+func (d *FixedDegree) Degree(rnd *rand.Rand) float64 {
+	return d.Value
+}
+
+func (d *FixedDegree) Update(outcome Outcome) {
+}
+
+// The `UniformDegree` draws the degree of destruction uniformly from [Min, Max] in every iteration.
+type UniformDegree struct {
+	Min float64
+	Max float64
+}
+
+func NewUniformDegree(minDegree, maxDegree float64) (UniformDegree, error) {
+	d := UniformDegree{Min: minDegree, Max: maxDegree}
+	if err := validateDegreeRange(d.Min, d.Max); err != nil {
+		return UniformDegree{}, err
+	}
+	return d, nil
+}
+
+func (d *UniformDegree) Degree(rnd *rand.Rand) float64 {
+	return d.Min + rnd.Float64()*(d.Max-d.Min)
+}
+
+func (d *UniformDegree) Update(outcome Outcome) {
+}
+
+// The `AdaptiveDegree` grows the degree of destruction during stagnation and shrinks it after
+// improvements. It starts at Min; after Patience iterations without a new best or a better solution
+// the degree is multiplied by Increase and after every improvement it is multiplied by Decrease,
+// the degree stays within [Min, Max].
+type AdaptiveDegree struct {
+	Min        float64 // the lower bound of the degree
+	Max        float64 // the upper bound of the degree
+	Increase   float64 // the factor applied during stagnation, greater than 1
+	Decrease   float64 // the factor applied after an improvement, in (0, 1]
+	Patience   int     // the number of iterations without improvement before the degree grows
+	degree     float64
+	stagnation int
+}
+
+func NewAdaptiveDegree(minDegree, maxDegree float64, patience int) (AdaptiveDegree, error) {
+	d := AdaptiveDegree{
+		Min:      minDegree,
+		Max:      maxDegree,
+		Increase: 1.2,
+		Decrease: 0.85,
+		Patience: patience,
+	}
+	if err := d.validate(); err != nil {
+		return AdaptiveDegree{}, err
+	}
+	return d, nil
+}
+
+func (d *AdaptiveDegree) validate() error {
+	if err := validateDegreeRange(d.Min, d.Max); err != nil {
+		return err
+	}
+	if d.Increase <= 1 {
+		return newValidationError("increase", "increase factor not greater than 1 not understood")
+	}
+	if !(0 < d.Decrease && d.Decrease <= 1) {
+		return newValidationError("decrease", "decrease factor outside (0, 1] not understood")
+	}
+	if d.Patience <= 0 {
+		return newValidationError("patience", "patience must be positive")
+	}
+	return nil
+}
+
+func (d *AdaptiveDegree) Degree(rnd *rand.Rand) float64 {
+	if d.degree == 0 {
+		d.degree = d.Min
+	}
+	return d.degree
+}
+
+func (d *AdaptiveDegree) Update(outcome Outcome) {
+	if d.degree == 0 {
+		d.degree = d.Min
+	}
+	if outcome == Best || outcome == Better {
+		d.degree = max(d.degree*d.Decrease, d.Min)
+		d.stagnation = 0
+		return
+	}
+	d.stagnation++
+	if d.stagnation >= d.Patience {
+		d.degree = min(d.degree*d.Increase, d.Max)
+		d.stagnation = 0
+	}
+}
+
+func validateDegreeRange(minDegree, maxDegree float64) error {
+	if !(0 < minDegree && minDegree <= 1) {
+		return newValidationError("min", "min degree outside (0, 1] not understood")
+	}
+	if !(minDegree <= maxDegree && maxDegree <= 1) {
+		return newValidationError("max", "max degree outside [min degree, 1] not understood")
+	}
+	return nil
+}
+
+// SizedOperator is a destroy operator that removes the given fraction (degree) of the solution.
+type SizedOperator func(state State, rnd *rand.Rand, degree float64) (State, error)
+
+// The `Destruction` passes the degree of destruction to the destroy operators. Set it as
+// ALNS.Destruction and wrap the sized destroy operators with Operator: the engine draws the degree
+// before the destroy operator is applied and reports the outcome of the iteration afterwards,
+// the chosen degrees are recorded in Statistics.Destructions if ALNS.CollectObjectives is set.
+//
+// A Destruction belongs to a single run, it is not safe for concurrent use.
+type Destruction struct {
+	degree  DegreeOfDestruction
+	current float64
+}
+
+func NewDestruction(degree DegreeOfDestruction) *Destruction {
+	return &Destruction{
+		degree: degree,
+	}
+}
+
+// Degree returns the degree of destruction of the current iteration (zero before the first one).
+func (d *Destruction) Degree() float64 {
+	return d.current
+}
+
+// Operator adapts the sized destroy operator to an Operator that removes the current degree.
+func (d *Destruction) Operator(op SizedOperator) Operator {
+	return func(state State, rnd *rand.Rand) (State, error) {
+		return op(state, rnd, d.current)
+	}
+}
+
+func (d *Destruction) next(rnd *rand.Rand) float64 {
+	d.current = d.degree.Degree(rnd)
+	return d.current
+}
+
+func (d *Destruction) update(outcome Outcome) {
+	d.degree.Update(outcome)
+}
@@ -0,0 +1,154 @@
+package alns
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+func TestDegreeOfDestructionValidation(t *testing.T) {
+	if _, err := NewFixedDegree(0); err == nil {
+		t.Error("an error expected for a zero degree")
+	}
+	if _, err := NewFixedDegree(1.5); err == nil {
+		t.Error("an error expected for a degree above 1")
+	}
+	if _, err := NewUniformDegree(0.3, 0.2); err == nil {
+		t.Error("an error expected for max below min")
+	}
+	if _, err := NewAdaptiveDegree(0.1, 0.5, 0); err == nil {
+		t.Error("an error expected for a zero patience")
+	}
+	if _, err := NewAdaptiveDegree(0.1, 0.5, 10); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUniformDegree(t *testing.T) {
+	rnd := rand.New(rand.NewPCG(1, 2))
+	d, err := NewUniformDegree(0.1, 0.3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	low, high := 1.0, 0.0
+	for range 1000 {
+		degree := d.Degree(rnd)
+		if degree < 0.1 || degree > 0.3 {
+			t.Fatalf("the degree %f outside [0.1, 0.3]", degree)
+		}
+		low, high = min(low, degree), max(high, degree)
+	}
+	if low > 0.12 || high < 0.28 {
+		t.Fatalf("the degrees expected to cover the range, actual [%f, %f]", low, high)
+	}
+}
+
+func TestAdaptiveDegree(t *testing.T) {
+	rnd := rand.New(rand.NewPCG(1, 2))
+	d, err := NewAdaptiveDegree(0.1, 0.2, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if degree := d.Degree(rnd); degree != 0.1 {
+		t.Fatalf("the initial degree 0.1 expected, actual %f", degree)
+	}
+
+	// the degree grows after every two iterations without improvement until it reaches max
+	d.Update(Reject)
+	if degree := d.Degree(rnd); degree != 0.1 {
+		t.Fatalf("the degree 0.1 expected before the patience is exhausted, actual %f", degree)
+	}
+	d.Update(Accept)
+	if degree := d.Degree(rnd); degree != 0.1*1.2 {
+		t.Fatalf("the degree %f expected, actual %f", 0.1*1.2, d.Degree(rnd))
+	}
+	for range 20 {
+		d.Update(Reject)
+	}
+	if degree := d.Degree(rnd); degree != 0.2 {
+		t.Fatalf("the degree capped at 0.2 expected, actual %f", degree)
+	}
+
+	// an improvement shrinks the degree and resets the stagnation
+	d.Update(Reject)
+	d.Update(Better)
+	if degree := d.Degree(rnd); degree != 0.2*0.85 {
+		t.Fatalf("the degree %f expected, actual %f", 0.2*0.85, degree)
+	}
+	d.Update(Reject)
+	if degree := d.Degree(rnd); degree != 0.2*0.85 {
+		t.Fatalf("the stagnation expected to be reset, actual degree %f", degree)
+	}
+	for range 20 {
+		d.Update(Best)
+	}
+	if degree := d.Degree(rnd); degree != 0.1 {
+		t.Fatalf("the degree bounded by 0.1 expected, actual %f", degree)
+	}
+}
+
+func TestAlnsDestruction(t *testing.T) {
+	degree, err := NewUniformDegree(0.1, 0.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	destruction := NewDestruction(&degree)
+	var passed []float64
+	a := ALNS{
+		Rnd:               rand.New(rand.NewPCG(1, 2)),
+		CollectObjectives: true,
+		DestroyOperators: []Operator{
+			destruction.Operator(func(state State, rnd *rand.Rand, degree float64) (State, error) {
+				passed = append(passed, degree)
+				return &FakeState{objective: state.Objective() * (1 - degree)}, nil
+			}),
+		},
+		RepairOperators: []Operator{
+			func(state State, rnd *rand.Rand) (State, error) { return state, nil },
+		},
+		Destruction: destruction,
+	}
+	selector, err := NewRouletteWheel([4]float64{3, 2, 1, 0.5}, 0.8, 1, 1, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := a.Iterate(&FakeState{objective: 100}, &selector, &HillClimbing{}, &MaxIterations{MaxIterations: 50})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	events := result.Statistics.Destructions
+	if len(events) != 50 || len(passed) != 50 {
+		t.Fatalf("50 destructions expected, actual %d recorded and %d passed", len(events), len(passed))
+	}
+	for i, event := range events {
+		if event.Iteration != i+1 || event.Degree != passed[i] {
+			t.Fatalf("the event %+v does not match the iteration %d with the degree %f", event, i+1, passed[i])
+		}
+		if event.Outcome != Best {
+			t.Fatalf("the outcome Best expected, actual %s", event.Outcome)
+		}
+	}
+	if destruction.Degree() != passed[len(passed)-1] {
+		t.Fatalf("the current degree %f expected, actual %f", passed[len(passed)-1], destruction.Degree())
+	}
+
+	a.CollectObjectives = false
+	result, err = a.Iterate(&FakeState{objective: 100}, &selector, &HillClimbing{}, &MaxIterations{MaxIterations: 50})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Statistics.Destructions) != 0 {
+		t.Fatalf("no destructions expected without CollectObjectives, actual %d", len(result.Statistics.Destructions))
+	}
+}
+
+func TestMultiStartDestruction(t *testing.T) {
+	degree := FixedDegree{Value: 0.1}
+	a := ALNS{Destruction: NewDestruction(&degree)}
+	factory := func(seed uint64) (RunSetup, error) {
+		return RunSetup{}, nil
+	}
+	if _, err := MultiStart(a, factory, []uint64{1, 2}, 1); err == nil {
+		t.Fatal("an error expected for a destruction shared by the runs")
+	}
+}
@@ -26,8 +26,12 @@ func main() {
 	// rnd := alns.RuntimeRand
 
 	// var initSol alns.State
+	regretInsertion, err := alns.RegretInsertion(2, 0, 0)
+	if err != nil {
+		panic(err)
+	}
 	initSol := NewTspState(nodes, map[int]int{}, dists)
-	if initSolG, err := greedyRepair(initSol, rnd); err != nil {
+	if initSolG, err := regretInsertion(initSol, rnd); err != nil {
 		panic(err)
 	} else {
 		initSol = initSolG.(*TspState)
@@ -37,9 +41,18 @@ func main() {
 	fmt.Printf("initial solution: %.4f\n", initSol.Objective())
 
 	destroyOperatorNames := []string{"randomRemoval", "pathRemoval", "worstRemoval"}
-	destroyOperators := []alns.Operator{randomRemoval, pathRemoval, worstRemoval}
-	repairOperatorNames := []string{"greedyRepair"}
-	repairOperators := []alns.Operator{greedyRepair}
+	degree, err := alns.NewFixedDegree(0.1)
+	if err != nil {
+		panic(err)
+	}
+	destruction := alns.NewDestruction(&degree)
+	destroyOperators := []alns.Operator{
+		destruction.Operator(randomRemoval),
+		destruction.Operator(pathRemoval),
+		destruction.Operator(worstRemoval),
+	}
+	repairOperatorNames := []string{"regretInsertion"}
+	repairOperators := []alns.Operator{regretInsertion}
 
 	sel, err := alns.NewRouletteWheel(
 		[4]float64{3, 2, 1, 0.5},
@@ -59,6 +72,7 @@ func main() {
 		Rnd:              rnd,
 		DestroyOperators: destroyOperators,
 		RepairOperators:  repairOperators,
+		Destruction:      destruction,
 	}
 
 	result, err := a.Iterate(initSol, &sel, &accept, &stop)
@@ -78,11 +92,11 @@ func main() {
 	)
 	fmt.Println("  destroy operators")
 	for i, name := range destroyOperatorNames {
-		fmt.Printf("    %d: %14s; %s\n", i, name, statistics.DestroyOperatorCounts[i])
+		fmt.Printf("    %d: %15s; %s\n", i, name, statistics.DestroyOperatorCounts[i])
 	}
 	fmt.Println("  repair operators")
 	for i, name := range repairOperatorNames {
-		fmt.Printf("    %d: %14s; %s\n", i, name, statistics.RepairOperatorCounts[i])
+		fmt.Printf("    %d: %15s; %s\n", i, name, statistics.RepairOperatorCounts[i])
 	}
 	if len(statistics.Objectives) > 0 {
 		fmt.Println("objectives")
@@ -257,7 +271,10 @@ type TspState struct {
 	objective float64
 }
 
-var _ alns.State = &TspState{}
+var (
+	_ alns.State      = &TspState{}
+	_ alns.Insertable = &TspState{}
+)
 
 func NewTspState(nodes []int, edges map[int]int, dists [][]float64) *TspState {
 	return &TspState{
@@ -305,80 +322,67 @@ func (s *TspState) Objective() float64 {
 	return s.objective
 }
 
-func greedyRepair(state alns.State, rnd *rand.Rand) (alns.State, error) {
-	current := state.(*TspState)
-
-	visited := make(map[int]bool, len(current.nodes))
-	for _, v := range current.edges {
-		visited[v] = true
+// Unassigned returns the nodes without the outgoing edge.
+func (s *TspState) Unassigned() []int {
+	var unassigned []int
+	for _, node := range s.nodes {
+		if _, ok := s.edges[node]; !ok {
+			unassigned = append(unassigned, node)
+		}
 	}
+	return unassigned
+}
 
-	shuffledIndices := rnd.Perm(len(current.nodes))
-	nodes := make([]int, len(shuffledIndices))
-	for i, ni := range shuffledIndices {
-		nodes[i] = current.nodes[ni]
+// Insertions returns the edges from the node to the nodes without the incoming edge that do not
+// close a subcycle, the position is the node the edge goes to.
+func (s *TspState) Insertions(node int) []alns.Insertion {
+	incoming := make(map[int]int, len(s.edges))
+	for from, to := range s.edges {
+		incoming[to] = from
 	}
-
-	for len(current.edges) != len(current.nodes) {
-		var node = -1
-		for _, other := range nodes {
-			if _, ok := current.edges[other]; !ok {
-				node = other
-				break
-			}
-		}
-		if node == -1 {
-			panic(fmt.Errorf("node not found"))
+	// the node ends a path, the edge to its first node closes the path
+	first := node
+	for {
+		prev, ok := incoming[first]
+		if !ok {
+			break
 		}
+		first = prev
+	}
+	closing := len(s.edges) == len(s.nodes)-1
 
-		var unvisited []int
-		for _, other := range current.nodes {
-			if other != node && !visited[other] && !wouldFormSubcycle(node, other, current) {
-				unvisited = append(unvisited, other)
-			}
-		}
-		if len(unvisited) == 0 {
-			panic(fmt.Errorf("unvisited is empty"))
+	var insertions []alns.Insertion
+	for _, other := range s.nodes {
+		if _, ok := incoming[other]; ok || (other == first) != closing {
+			continue
 		}
-
-		nearest := slices.MinFunc(unvisited, func(a, b int) int {
-			return cmp.Compare(current.dists[node][a], current.dists[node][b])
-		})
-
-		current.edges[node] = nearest
-		visited[nearest] = true
+		insertions = append(insertions, alns.Insertion{Position: other, Cost: s.dists[node][other]})
 	}
-
-	return state, nil
+	return insertions
 }
 
-func wouldFormSubcycle(fromNode, toNode int, state *TspState) bool {
-	for step := 1; step < len(state.nodes); step++ {
-		if _, ok := state.edges[toNode]; !ok {
-			return false
-		}
-		toNode = state.edges[toNode]
-		if fromNode == toNode && step != len(state.nodes)-1 {
-			return true
-		}
-	}
-	return false
+// Insert adds the edge from the node to the node returned by Insertions.
+func (s *TspState) Insert(node, to int) {
+	s.edges[node] = to
+	s.objective = math.NaN()
 }
 
-const DegreeOfDestruction = 0.1
+func (s *TspState) CloneInsertable() alns.Insertable {
+	return s.Clone()
+}
 
-func edgesToRemove(state *TspState) int {
-	n := int(float64(len(state.edges)) * DegreeOfDestruction)
+func edgesToRemove(state *TspState, degree float64) int {
+	n := int(float64(len(state.edges)) * degree)
 	if n == 0 {
 		return 1
 	}
 	return n
 }
 
-func randomRemoval(state alns.State, rnd *rand.Rand) (alns.State, error) {
+func randomRemoval(state alns.State, rnd *rand.Rand, degree float64) (alns.State, error) {
 	destroyed := state.(*TspState).Clone()
 
-	toRemove := edgesToRemove(destroyed)
+	toRemove := edgesToRemove(destroyed, degree)
 
 	removed := 0
 	for removed != toRemove {
@@ -393,13 +397,13 @@ func randomRemoval(state alns.State, rnd *rand.Rand) (alns.State, error) {
 	return destroyed, nil
 }
 
-func pathRemoval(state alns.State, rnd *rand.Rand) (alns.State, error) {
+func pathRemoval(state alns.State, rnd *rand.Rand, degree float64) (alns.State, error) {
 	destroyed := state.(*TspState).Clone()
 
 	nodeIdx := rnd.IntN(len(destroyed.nodes))
 	node := destroyed.nodes[nodeIdx]
 
-	toRemove := edgesToRemove(destroyed)
+	toRemove := edgesToRemove(destroyed, degree)
 
 	for range toRemove {
 		nextNode := destroyed.edges[node]
@@ -410,7 +414,7 @@ func pathRemoval(state alns.State, rnd *rand.Rand) (alns.State, error) {
 	return destroyed, nil
 }
 
-func worstRemoval(state alns.State, rnd *rand.Rand) (alns.State, error) {
+func worstRemoval(state alns.State, rnd *rand.Rand, degree float64) (alns.State, error) {
 	destroyed := state.(*TspState).Clone()
 
 	worstEdges := slices.Clone(destroyed.nodes)
@@ -421,7 +425,7 @@ func worstRemoval(state alns.State, rnd *rand.Rand) (alns.State, error) {
 		)
 	})
 
-	toRemove := edgesToRemove(destroyed)
+	toRemove := edgesToRemove(destroyed, degree)
 	for idx := range toRemove {
 		delete(destroyed.edges, worstEdges[len(worstEdges)-(idx+1)])
 	}
@@ -0,0 +1,154 @@
+package alns
+
+import (
+	"math/rand/v2"
+)
+
+// Feasibility is an optional extension of State for problems with constraints.
+type Feasibility interface {
+	Feasible() bool
+}
+
+// ConstraintViolation is an optional extension of State that measures how much a solution
+// violates the constraints. A solution without violation (zero or less) is feasible.
+type ConstraintViolation interface {
+	Violation() float64
+}
+
+func isFeasible(state State) bool {
+	if f, ok := state.(Feasibility); ok {
+		return f.Feasible()
+	}
+	if v, ok := state.(ConstraintViolation); ok {
+		return v.Violation() <= 0
+	}
+	return true
+}
+
+func violation(state State) float64 {
+	if v, ok := state.(ConstraintViolation); ok {
+		return max(v.Violation(), 0)
+	}
+	if !isFeasible(state) {
+		return 1
+	}
+	return 0
+}
+
+// The `AdaptivePenalty` penalizes infeasible solutions by adding `Weight * Violation()` to the objective.
+// The weight is adjusted after every Window candidates: it is multiplied by Increase if the ratio
+// of feasible candidates is below TargetRatio and by Decrease otherwise. MultiStart and Replay start
+// every run from the weight the penalty had when it was first used by a run.
+type AdaptivePenalty struct {
+	Weight      float64 // the current penalty weight, positive
+	MinWeight   float64 // the lower bound of the weight
+	MaxWeight   float64 // the upper bound of the weight, zero means no bound
+	TargetRatio float64 // the desired ratio of feasible candidates
+	Increase    float64 // the factor applied when there are too few feasible candidates, greater than 1
+	Decrease    float64 // the factor applied when there are enough feasible candidates, in (0, 1]
+	Window      int     // the number of candidates between weight updates
+	initial     float64 // the weight at the start of the first run
+	feasible    int
+	registered  int
+}
+
+func NewAdaptivePenalty(weight, targetRatio float64, window int) (AdaptivePenalty, error) {
+	p := AdaptivePenalty{
+		Weight:      weight,
+		TargetRatio: targetRatio,
+		Increase:    1.2,
+		Decrease:    0.85,
+		Window:      window,
+	}
+	if err := p.validate(); err != nil {
+		return AdaptivePenalty{}, err
+	}
+	return p, nil
+}
+
+func (p *AdaptivePenalty) validate() error {
+	if p.Weight <= 0 {
+		return newValidationError("weight", "non-positive weight is not understood")
+	}
+	if !(0 <= p.TargetRatio && p.TargetRatio <= 1) {
+		return newValidationError("targetRatio", "target ratio outside [0, 1] not understood")
+	}
+	if p.Increase <= 1 {
+		return newValidationError("increase", "increase factor not greater than 1 not understood")
+	}
+	if !(0 < p.Decrease && p.Decrease <= 1) {
+		return newValidationError("decrease", "decrease factor outside (0, 1] not understood")
+	}
+	if p.Window <= 0 {
+		return newValidationError("window", "window must be positive")
+	}
+	return nil
+}
+
+// start remembers the weight at the start of the first run.
+func (p *AdaptivePenalty) start() {
+	if p.initial == 0 {
+		p.initial = p.Weight
+	}
+}
+
+// fresh returns a copy of the penalty with the weight at the start of the first run.
+func (p *AdaptivePenalty) fresh() AdaptivePenalty {
+	fresh := *p
+	fresh.start()
+	fresh.Weight = fresh.initial
+	fresh.feasible, fresh.registered = 0, 0
+	return fresh
+}
+
+// Objective returns the penalized objective of the state.
+func (p *AdaptivePenalty) Objective(state State) float64 {
+	return state.Objective() + p.Weight*violation(state)
+}
+
+func (p *AdaptivePenalty) register(feasible bool) {
+	p.registered++
+	if feasible {
+		p.feasible++
+	}
+	if p.registered < p.Window {
+		return
+	}
+
+	ratio := float64(p.feasible) / float64(p.registered)
+	if ratio < p.TargetRatio {
+		p.Weight *= p.Increase
+	} else {
+		p.Weight *= p.Decrease
+	}
+	p.Weight = max(p.Weight, p.MinWeight)
+	if p.MaxWeight > 0 {
+		p.Weight = min(p.Weight, p.MaxWeight)
+	}
+	p.feasible = 0
+	p.registered = 0
+}
+
+// penalizedState is passed to the acceptance criterion instead of the original state when
+// the penalty is enabled.
+type penalizedState struct {
+	State
+	objective float64
+}
+
+func (s *penalizedState) Objective() float64 {
+	return s.objective
+}
+
+type penalizedAcceptance struct {
+	accept  AcceptanceCriterion
+	penalty *AdaptivePenalty
+}
+
+func (a *penalizedAcceptance) Accept(rnd *rand.Rand, best, current, candidate State) (bool, error) {
+	return a.accept.Accept(rnd,
+		&penalizedState{State: best, objective: a.penalty.Objective(best)},
+		&penalizedState{State: current, objective: a.penalty.Objective(current)},
+		&penalizedState{State: candidate, objective: a.penalty.Objective(candidate)},
+	)
+}
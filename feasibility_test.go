@@ -0,0 +1,152 @@
+package alns
+
+import (
+	"errors"
+	"math/rand/v2"
+	"testing"
+)
+
+type ConstrainedState struct {
+	objective float64
+	violation float64
+}
+
+func (s *ConstrainedState) Objective() float64 {
+	return s.objective
+}
+
+func (s *ConstrainedState) Violation() float64 {
+	return s.violation
+}
+
+func TestDetermineOutcomeInfeasible(t *testing.T) {
+	a := ALNS{Rnd: rand.New(rand.NewPCG(1, 2))}
+	accept := AcceptAll{}
+
+	tests := []struct {
+		best, curr, cand *ConstrainedState
+		want             Outcome
+	}{
+		{&ConstrainedState{2, 0}, &ConstrainedState{3, 0}, &ConstrainedState{1, 0}, Best},
+		{&ConstrainedState{2, 0}, &ConstrainedState{3, 0}, &ConstrainedState{1, 1}, Better},
+		{&ConstrainedState{2, 1}, &ConstrainedState{2, 1}, &ConstrainedState{3, 0}, Best},
+		{&ConstrainedState{2, 1}, &ConstrainedState{2, 1}, &ConstrainedState{1, 1}, Better},
+	}
+	for i, tt := range tests {
+		got, _, err := a.determineOutcome(&accept, tt.best, tt.curr, tt.cand)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.want {
+			t.Errorf("test %d: outcome %s expected, actual %s", i, tt.want, got)
+		}
+	}
+}
+
+func TestAlnsBestFeasible(t *testing.T) {
+	// the candidates with the objective below the threshold are infeasible
+	solve := func(penalty *AdaptivePenalty, threshold float64) *Result {
+		opSelect, _ := NewRouletteWheel([4]float64{3, 2, 1, 0.5}, 0.8, 1, 1, nil)
+		accept := HillClimbing{}
+		stop := MaxIterations{MaxIterations: 200}
+		a := ALNS{
+			Rnd: rand.New(rand.NewPCG(1, 2)),
+			DestroyOperators: []Operator{
+				func(state State, rnd *rand.Rand) (State, error) { return &ConstrainedState{}, nil },
+			},
+			RepairOperators: []Operator{
+				func(state State, rnd *rand.Rand) (State, error) {
+					cand := state.(*ConstrainedState)
+					cand.objective = rnd.Float64()
+					if cand.objective < threshold {
+						cand.violation = threshold - cand.objective
+					}
+					return cand, nil
+				},
+			},
+			Penalty: penalty,
+		}
+		res, err := a.Iterate(&ConstrainedState{objective: 10, violation: 1}, &opSelect, &accept, &stop)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return res
+	}
+
+	t.Run("WithoutPenalty", func(t *testing.T) {
+		res := solve(nil, 0.5)
+		if !isFeasible(res.BestState) || res.BestFeasibleState != res.BestState {
+			t.Fatalf("the best solution %f is infeasible", res.BestState.Objective())
+		}
+		if res.Statistics.InfeasibleCount == 0 {
+			t.Fatal("infeasible candidates expected")
+		}
+	})
+
+	t.Run("WithPenalty", func(t *testing.T) {
+		penalty, err := NewAdaptivePenalty(1, 0.5, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res := solve(&penalty, 0.5)
+		if res.BestFeasibleState == nil || res.BestFeasibleState.Objective() < 0.5 {
+			t.Fatal("a feasible solution expected")
+		}
+		if penalty.Weight == 1 {
+			t.Fatal("the penalty weight was not adjusted")
+		}
+	})
+
+	t.Run("NoFeasible", func(t *testing.T) {
+		res := solve(nil, 2)
+		if res.BestFeasibleState != nil || res.BestState.Objective() != 10 {
+			t.Fatalf("the infeasible initial solution without a best feasible solution expected, actual %v", res.BestFeasibleState)
+		}
+	})
+}
+
+func TestAdaptivePenalty(t *testing.T) {
+	t.Run("Validation", func(t *testing.T) {
+		_, err := NewAdaptivePenalty(-1, 0.5, 10)
+		if err == nil || err.Error() != "non-positive weight is not understood" {
+			t.Fatalf("is not valid: %s", err)
+		}
+		_, err = NewAdaptivePenalty(0, 0.5, 10)
+		if err == nil || err.Error() != "non-positive weight is not understood" {
+			t.Fatalf("is not valid: %s", err)
+		}
+		_, err = NewAdaptivePenalty(1, 1.5, 10)
+		if err == nil || err.Error() != "target ratio outside [0, 1] not understood" {
+			t.Fatalf("is not valid: %s", err)
+		}
+		_, err = NewAdaptivePenalty(1, 0.5, 0)
+		var verr *validationError
+		if !errors.As(err, &verr) || verr.field != "window" || err.Error() != "window must be positive" {
+			t.Fatalf("is not valid: %s", err)
+		}
+		penalty := AdaptivePenalty{Weight: 1, TargetRatio: 0.5, Increase: 1, Decrease: 0.85, Window: 10}
+		if err := penalty.validate(); !errors.As(err, &verr) || verr.field != "increase" {
+			t.Fatalf("the increase factor 1 expected to be invalid, actual %v", err)
+		}
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		penalty, _ := NewAdaptivePenalty(10, 0.5, 4)
+		for _, feasible := range []bool{false, false, false, true} {
+			penalty.register(feasible)
+		}
+		if penalty.Weight != 12 {
+			t.Fatalf("weight 12 expected, actual %f", penalty.Weight)
+		}
+		for _, feasible := range []bool{true, true, false, true} {
+			penalty.register(feasible)
+		}
+		if penalty.Weight != 12*0.85 {
+			t.Fatalf("weight %f expected, actual %f", 12*0.85, penalty.Weight)
+		}
+		state := ConstrainedState{objective: 1, violation: 2}
+		if got := penalty.Objective(&state); got != 1+2*12*0.85 {
+			t.Fatalf("penalized objective %f expected, actual %f", 1+2*12*0.85, got)
+		}
+	})
+}
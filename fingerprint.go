@@ -0,0 +1,33 @@
+package alns
+
+import "fmt"
+
+// Fingerprinter is an optional extension of State used to detect operators that mutate their input
+// (see ALNS.CheckOperators). The fingerprint must change whenever the solution changes.
+type Fingerprinter interface {
+	Fingerprint() uint64
+}
+
+// StateMutationError is returned when an operator has mutated the current solution instead of a clone.
+type StateMutationError struct {
+	Operator string // the operator during whose call the current solution was mutated
+}
+
+func (e *StateMutationError) Error() string {
+	return fmt.Sprintf("%s mutated the current solution", e.Operator)
+}
+
+// checkFingerprint returns StateMutationError if the fingerprint of the state differs from the expected one.
+func checkFingerprint(state State, expected uint64, operator string) error {
+	if f, ok := state.(Fingerprinter); ok && f.Fingerprint() != expected {
+		return &StateMutationError{Operator: operator}
+	}
+	return nil
+}
+
+func fingerprint(state State) (uint64, bool) {
+	if f, ok := state.(Fingerprinter); ok {
+		return f.Fingerprint(), true
+	}
+	return 0, false
+}
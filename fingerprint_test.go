@@ -0,0 +1,80 @@
+package alns
+
+import (
+	"errors"
+	"math/rand/v2"
+	"testing"
+)
+
+type FingerprintState struct {
+	values []int
+}
+
+func (s *FingerprintState) Objective() float64 {
+	v := 0
+	for _, value := range s.values {
+		v += value
+	}
+	return float64(v)
+}
+
+func (s *FingerprintState) Fingerprint() uint64 {
+	h := uint64(17)
+	for _, value := range s.values {
+		h = h*31 + uint64(value)
+	}
+	return h
+}
+
+func TestAlnsCheckOperators(t *testing.T) {
+	mutate := func(state State, rnd *rand.Rand) (State, error) {
+		s := state.(*FingerprintState)
+		s.values[rnd.IntN(len(s.values))] = rnd.IntN(10)
+		return s, nil
+	}
+	clone := func(state State, rnd *rand.Rand) (State, error) {
+		s := state.(*FingerprintState)
+		return &FingerprintState{values: append([]int{}, s.values...)}, nil
+	}
+	identity := func(state State, rnd *rand.Rand) (State, error) {
+		return state, nil
+	}
+
+	solve := func(destroyOp, repairOp Operator) error {
+		opSelect, _ := NewRouletteWheel([4]float64{3, 2, 1, 0.5}, 0.8, 1, 1, nil)
+		accept := AcceptAll{}
+		stop := MaxIterations{MaxIterations: 100}
+		a := ALNS{
+			Rnd:              rand.New(rand.NewPCG(1, 2)),
+			DestroyOperators: []Operator{destroyOp},
+			RepairOperators:  []Operator{repairOp},
+			CheckOperators:   true,
+		}
+		_, err := a.Iterate(&FingerprintState{values: []int{5, 5, 5, 5}}, &opSelect, &accept, &stop)
+		return err
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		if err := solve(clone, mutate); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("Destroy", func(t *testing.T) {
+		err := solve(mutate, mutate)
+		var mutation *StateMutationError
+		if !errors.As(err, &mutation) {
+			t.Fatalf("StateMutationError expected, actual %v", err)
+		}
+		if err.Error() != "destroy operator 0 mutated the current solution" {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	})
+
+	t.Run("Repair", func(t *testing.T) {
+		err := solve(identity, mutate)
+		if err == nil || err.Error() != "repair operator 0 mutated the current solution" {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
@@ -0,0 +1,62 @@
+package alns
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// LocalSearch improves a repaired candidate before it is evaluated, e.g. 2-opt for TSP.
+// Like operators, it must not mutate the state if it is shared, but it can return the state itself.
+// A DeltaEvaluated state gets its objective from the delta accumulated by the local search, whether
+// it is improved in place or a new state; the objective of a state improved in place is always verified.
+type LocalSearch func(state State, rnd *rand.Rand) (State, error)
+
+type LocalSearchStatistics struct {
+	Calls        int           // the number of calls
+	Improvements int           // the number of calls that improved the candidate
+	Improvement  float64       // the total decrease of the objective
+	Runtime      time.Duration // the total runtime
+}
+
+func (l LocalSearchStatistics) String() string {
+	return fmt.Sprintf("{Calls:%d Improvements:%d Improvement:%f Runtime:%s}",
+		l.Calls, l.Improvements, l.Improvement, l.Runtime)
+}
+
+// isPromising reports whether the local search should be applied to the candidate.
+func (a *ALNS) isPromising(best, cand State) bool {
+	if a.LocalSearchProbability > 0 && a.streams().engine.Float64() >= a.LocalSearchProbability {
+		return false
+	}
+	if a.LocalSearchGap > 0 {
+		gap := cand.Objective() - best.Objective()
+		if b := math.Abs(best.Objective()); b > 0 {
+			gap /= b
+		}
+		return gap <= a.LocalSearchGap
+	}
+	return true
+}
+
+func (a *ALNS) improve(best, cand State, stats *Statistics) (State, error) {
+	if len(a.LocalSearches) == 0 || !a.isPromising(best, cand) {
+		return cand, nil
+	}
+	for i, localSearch := range a.LocalSearches {
+		started := time.Now()
+		before := cand.Objective()
+		improved, err := localSearch(cand, a.streams().localSearch[i])
+		if err != nil {
+			return nil, err
+		}
+		check := a.deltaCheck(stats) || improved == cand
+		if err := a.applyDelta(cand, improved, localSearchName(i), check, stats); err != nil {
+			return nil, err
+		}
+		stats.collectLocalSearch(i, time.Since(started), before-improved.Objective())
+		cand = improved
+	}
+	return cand, nil
+}
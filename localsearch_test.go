@@ -0,0 +1,72 @@
+package alns
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+func TestAlnsLocalSearch(t *testing.T) {
+	solve := func(a ALNS) *Result {
+		opSelect, _ := NewRouletteWheel([4]float64{3, 2, 1, 0.5}, 0.8, 1, 1, nil)
+		accept := AcceptAll{}
+		stop := MaxIterations{MaxIterations: 100}
+		a.Rnd = rand.New(rand.NewPCG(1, 2))
+		a.DestroyOperators = []Operator{
+			func(state State, rnd *rand.Rand) (State, error) { return state.(*FakeState).Clone(), nil },
+		}
+		a.RepairOperators = []Operator{
+			func(state State, rnd *rand.Rand) (State, error) {
+				state.(*FakeState).objective = 1 + rnd.Float64()
+				return state, nil
+			},
+		}
+		a.LocalSearches = []LocalSearch{
+			func(state State, rnd *rand.Rand) (State, error) {
+				state.(*FakeState).objective -= 0.5
+				return state, nil
+			},
+			func(state State, rnd *rand.Rand) (State, error) {
+				return state, nil
+			},
+		}
+		res, err := a.Iterate(&FakeState{objective: 10}, &opSelect, &accept, &stop)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return res
+	}
+
+	t.Run("Always", func(t *testing.T) {
+		res := solve(ALNS{})
+		improving := res.Statistics.LocalSearches[0]
+		if improving.Calls != 100 || improving.Improvements != 100 {
+			t.Fatalf("100 calls and improvements expected, actual %s", improving)
+		}
+		if improving.Improvement != 50 {
+			t.Fatalf("the total improvement 50 expected, actual %f", improving.Improvement)
+		}
+		idle := res.Statistics.LocalSearches[1]
+		if idle.Calls != 100 || idle.Improvements != 0 {
+			t.Fatalf("100 calls and no improvements expected, actual %s", idle)
+		}
+		if res.BestState.Objective() >= 1 {
+			t.Fatalf("the improved objective less than 1 expected, actual %f", res.BestState.Objective())
+		}
+	})
+
+	t.Run("Probability", func(t *testing.T) {
+		res := solve(ALNS{LocalSearchProbability: 0.3})
+		calls := res.Statistics.LocalSearches[0].Calls
+		if !(15 <= calls && calls <= 45) {
+			t.Fatalf("about 30 calls expected, actual %d", calls)
+		}
+	})
+
+	t.Run("Promising", func(t *testing.T) {
+		res := solve(ALNS{LocalSearchGap: 0.2})
+		calls := res.Statistics.LocalSearches[0].Calls
+		if !(0 < calls && calls < 100) {
+			t.Fatalf("only some candidates are expected to be improved, actual %d calls", calls)
+		}
+	})
+}
@@ -0,0 +1,79 @@
+package metrics
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// exposition writes the metric families in the Prometheus text format, see
+// https://prometheus.io/docs/instrumenting/exposition_formats/
+type exposition struct {
+	b         *strings.Builder
+	namespace string
+}
+
+func (e *exposition) family(name, typ, help string) {
+	e.b.WriteString("# HELP ")
+	e.name(name)
+	e.b.WriteByte(' ')
+	e.b.WriteString(strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(help))
+	e.b.WriteString("\n# TYPE ")
+	e.name(name)
+	e.b.WriteByte(' ')
+	e.b.WriteString(typ)
+	e.b.WriteByte('\n')
+}
+
+// sample writes a sample, the labels are pairs of names and values.
+func (e *exposition) sample(name string, labels []string, value float64) {
+	e.name(name)
+	if len(labels) > 0 {
+		e.b.WriteByte('{')
+		for i := 0; i < len(labels); i += 2 {
+			if i > 0 {
+				e.b.WriteByte(',')
+			}
+			e.b.WriteString(labels[i])
+			e.b.WriteString(`="`)
+			e.b.WriteString(labelReplacer.Replace(labels[i+1]))
+			e.b.WriteByte('"')
+		}
+		e.b.WriteByte('}')
+	}
+	e.b.WriteByte(' ')
+	e.b.WriteString(formatValue(value))
+	e.b.WriteByte('\n')
+}
+
+func (e *exposition) name(name string) {
+	e.b.WriteString(e.namespace)
+	e.b.WriteByte('_')
+	e.b.WriteString(name)
+}
+
+var labelReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func formatValue(v float64) string {
+	switch {
+	case math.IsNaN(v):
+		return "NaN"
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	default:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+}
+
+// validName reports whether the name matches [a-zA-Z_:][a-zA-Z0-9_:]*.
+func validName(name string) bool {
+	for i, c := range name {
+		letter := c == '_' || c == ':' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+		if !letter && (i == 0 || c < '0' || c > '9') {
+			return false
+		}
+	}
+	return name != ""
+}
@@ -0,0 +1,213 @@
+// Package metrics exports the progress of ALNS runs in the Prometheus text exposition format.
+//
+//	observer, _ := metrics.NewObserver("alns", nil)
+//	a.Observers = append(a.Observers, observer)
+//	http.Handle("/metrics", observer)
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bibenga/alns"
+)
+
+// DefaultBuckets are the upper bounds of the iteration latency histogram in seconds.
+var DefaultBuckets = []float64{0.00001, 0.0001, 0.0005, 0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1}
+
+var outcomes = []alns.Outcome{alns.Best, alns.Better, alns.Accept, alns.Reject}
+
+// The `Observer` is an alns.RunObserver that maintains the metrics of the runs and serves them as
+// an http.Handler. The counters accumulate over all runs, the gauges describe the last run.
+// It is safe to serve the metrics while a run is in progress.
+type Observer struct {
+	namespace string
+	buckets   []float64
+
+	mu           sync.Mutex
+	runs         uint64
+	running      bool
+	iterations   uint64
+	best         float64
+	current      float64
+	lastRuntime  time.Duration
+	destroy      []operatorMetrics
+	repair       []operatorMetrics
+	latencyCount []uint64 // the number of the latencies per bucket, the last bucket is +Inf
+	latencySum   float64
+}
+
+var _ alns.RunObserver = &Observer{}
+var _ http.Handler = &Observer{}
+
+type operatorMetrics struct {
+	name     string
+	outcomes alns.OperatorStatistics
+	weight   float64
+	weighted bool
+}
+
+// NewObserver creates the observer, the metric names are prefixed by the namespace (e.g. alns_iterations_total)
+// and nil buckets are DefaultBuckets.
+func NewObserver(namespace string, buckets []float64) (*Observer, error) {
+	if namespace == "" {
+		return nil, fmt.Errorf("empty namespace not understood")
+	}
+	if !validName(namespace) {
+		return nil, fmt.Errorf("namespace %q is not a valid metric name", namespace)
+	}
+	if buckets == nil {
+		buckets = DefaultBuckets
+	}
+	for i, bound := range buckets {
+		if math.IsNaN(bound) || math.IsInf(bound, 0) || (i > 0 && bound <= buckets[i-1]) {
+			return nil, fmt.Errorf("buckets must be finite and increasing, actual %v", buckets)
+		}
+	}
+	return &Observer{
+		namespace:    namespace,
+		buckets:      slices.Clone(buckets),
+		best:         math.NaN(),
+		current:      math.NaN(),
+		latencyCount: make([]uint64, len(buckets)+1),
+	}, nil
+}
+
+func (o *Observer) OnStart(event *alns.StartEvent) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.runs++
+	o.running = true
+	o.best = event.Initial.Objective()
+	o.current = o.best
+	o.lastRuntime = 0
+	o.destroy = o.operators(o.destroy, event.DestroyOperators)
+	o.repair = o.operators(o.repair, event.RepairOperators)
+	return nil
+}
+
+// operators returns the metrics of the named operators, the outcomes of the known names are kept.
+func (o *Observer) operators(previous []operatorMetrics, names []string) []operatorMetrics {
+	operators := make([]operatorMetrics, len(names))
+	for i, name := range names {
+		operators[i].name = name
+		for _, p := range previous {
+			if p.name == name {
+				operators[i].outcomes = p.outcomes
+			}
+		}
+	}
+	return operators
+}
+
+func (o *Observer) OnIteration(event *alns.IterationEvent) error {
+	best, current := event.Best.Objective(), event.Current.Objective()
+	destroyWeights, repairWeights := event.Weights()
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.iterations++
+	o.best, o.current = best, current
+	if event.Destroy < len(o.destroy) {
+		o.destroy[event.Destroy].outcomes[event.Outcome]++
+	}
+	if event.Repair < len(o.repair) {
+		o.repair[event.Repair].outcomes[event.Outcome]++
+	}
+	setWeights(o.destroy, destroyWeights)
+	setWeights(o.repair, repairWeights)
+
+	latency := (event.Runtime - o.lastRuntime).Seconds()
+	o.lastRuntime = event.Runtime
+	bucket, _ := slices.BinarySearch(o.buckets, latency)
+	o.latencyCount[bucket]++
+	o.latencySum += latency
+	return nil
+}
+
+func setWeights(operators []operatorMetrics, weights []float64) {
+	for i := range min(len(operators), len(weights)) {
+		operators[i].weight = weights[i]
+		operators[i].weighted = true
+	}
+}
+
+func (o *Observer) OnStop(event *alns.StopEvent) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.running = false
+}
+
+// ServeHTTP writes the metrics in the Prometheus text exposition format.
+func (o *Observer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var b strings.Builder
+	o.write(&b)
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_, _ = io.WriteString(w, b.String())
+}
+
+func (o *Observer) write(b *strings.Builder) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	e := exposition{b: b, namespace: o.namespace}
+	e.family("runs_total", "counter", "The number of started runs.")
+	e.sample("runs_total", nil, float64(o.runs))
+	e.family("running", "gauge", "Whether a run is in progress.")
+	e.sample("running", nil, boolValue(o.running))
+	e.family("iterations_total", "counter", "The number of finished iterations.")
+	e.sample("iterations_total", nil, float64(o.iterations))
+	e.family("best_objective", "gauge", "The objective of the best solution of the last run.")
+	e.sample("best_objective", nil, o.best)
+	e.family("current_objective", "gauge", "The objective of the current solution of the last run.")
+	e.sample("current_objective", nil, o.current)
+
+	kinds := []struct {
+		name      string
+		operators []operatorMetrics
+	}{{"destroy", o.destroy}, {"repair", o.repair}}
+	e.family("operator_outcomes_total", "counter", "The outcomes of the iterations per operator.")
+	for _, kind := range kinds {
+		for _, op := range kind.operators {
+			for _, outcome := range outcomes {
+				e.sample("operator_outcomes_total",
+					[]string{"kind", kind.name, "operator", op.name, "outcome", strings.ToLower(outcome.String())},
+					float64(op.outcomes[outcome]))
+			}
+		}
+	}
+	e.family("operator_weight", "gauge", "The weights of the operators in the selection scheme.")
+	for _, kind := range kinds {
+		for _, op := range kind.operators {
+			if op.weighted {
+				e.sample("operator_weight", []string{"kind", kind.name, "operator", op.name}, op.weight)
+			}
+		}
+	}
+
+	e.family("iteration_duration_seconds", "histogram", "The duration of the iterations.")
+	var cumulative uint64
+	for i, count := range o.latencyCount {
+		cumulative += count
+		le := math.Inf(1)
+		if i < len(o.buckets) {
+			le = o.buckets[i]
+		}
+		e.sample("iteration_duration_seconds_bucket", []string{"le", formatValue(le)}, float64(cumulative))
+	}
+	e.sample("iteration_duration_seconds_sum", nil, o.latencySum)
+	e.sample("iteration_duration_seconds_count", nil, float64(cumulative))
+}
+
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
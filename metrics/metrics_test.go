@@ -0,0 +1,188 @@
+package metrics
+
+import (
+	"bufio"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/bibenga/alns"
+)
+
+type objectiveState float64
+
+func (s objectiveState) Objective() float64 {
+	return float64(s)
+}
+
+func solve(t *testing.T, observer *Observer, iterations int) *alns.Result {
+	seeds := alns.NewSeedSequence(1)
+	a := alns.ALNS{
+		Seeds: &seeds,
+		DestroyOperators: []alns.Operator{
+			func(state alns.State, rnd *rand.Rand) (alns.State, error) { return state, nil },
+			func(state alns.State, rnd *rand.Rand) (alns.State, error) { return state, nil },
+		},
+		RepairOperators: []alns.Operator{
+			func(state alns.State, rnd *rand.Rand) (alns.State, error) { return objectiveState(rnd.Float64()), nil },
+		},
+		DestroyOperatorNames: []string{"random", `odd "name"`},
+		Observers:            []alns.Observer{observer},
+	}
+	selector, err := alns.NewRouletteWheel([4]float64{3, 2, 1, 0.5}, 0.8, 2, 1, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := a.Iterate(objectiveState(1), &selector, &alns.HillClimbing{}, &alns.MaxIterations{MaxIterations: iterations})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return result
+}
+
+// scrape returns the samples of the metrics endpoint by the metric name with the labels.
+func scrape(t *testing.T, url string) map[string]float64 {
+	response, err := http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+	if contentType := response.Header.Get("Content-Type"); !strings.HasPrefix(contentType, "text/plain; version=0.0.4") {
+		t.Fatalf("the text exposition format expected, actual %s", contentType)
+	}
+	samples := make(map[string]float64)
+	scanner := bufio.NewScanner(response.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		i := strings.LastIndexByte(line, ' ')
+		value, err := strconv.ParseFloat(line[i+1:], 64)
+		if err != nil {
+			t.Fatalf("the line %q: %s", line, err)
+		}
+		samples[line[:i]] = value
+	}
+	return samples
+}
+
+func TestObserver(t *testing.T) {
+	observer, err := NewObserver("alns", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(observer)
+	defer server.Close()
+
+	solve(t, observer, 40)
+	last := solve(t, observer, 10)
+
+	samples := scrape(t, server.URL)
+	for name, expected := range map[string]float64{
+		"alns_runs_total":                                   2,
+		"alns_running":                                      0,
+		"alns_iterations_total":                             50,
+		"alns_best_objective":                               last.BestState.Objective(),
+		"alns_iteration_duration_seconds_count":             50,
+		`alns_iteration_duration_seconds_bucket{le="+Inf"}`: 50,
+	} {
+		if actual, ok := samples[name]; !ok || actual != expected {
+			t.Errorf("%s: %f expected, actual %f", name, expected, actual)
+		}
+	}
+
+	destroy, repair := 0.0, 0.0
+	for name, value := range samples {
+		if strings.HasPrefix(name, `alns_operator_outcomes_total{kind="destroy"`) {
+			destroy += value
+		} else if strings.HasPrefix(name, `alns_operator_outcomes_total{kind="repair"`) {
+			repair += value
+		}
+	}
+	if destroy != 50 || repair != 50 {
+		t.Errorf("50 outcomes per operator kind expected, actual %f and %f", destroy, repair)
+	}
+	if _, ok := samples[`alns_operator_outcomes_total{kind="destroy",operator="odd \"name\"",outcome="best"}`]; !ok {
+		t.Error("the escaped operator name expected")
+	}
+	if _, ok := samples[`alns_operator_weight{kind="repair",operator="repair operator 0"}`]; !ok {
+		t.Error("the weight of the repair operator expected")
+	}
+
+	var bounds []string
+	for _, bound := range DefaultBuckets {
+		bounds = append(bounds, strconv.FormatFloat(bound, 'g', -1, 64))
+	}
+	previous := 0.0
+	for _, le := range append(bounds, "+Inf") {
+		count, ok := samples[`alns_iteration_duration_seconds_bucket{le="`+le+`"}`]
+		if !ok {
+			t.Fatalf("the bucket le=%s expected", le)
+		}
+		if count < previous {
+			t.Fatalf("the histogram buckets must be cumulative, le=%s", le)
+		}
+		previous = count
+	}
+}
+
+func TestObserverValidation(t *testing.T) {
+	for _, tc := range []struct {
+		namespace string
+		buckets   []float64
+	}{
+		{"", nil},
+		{"1alns", nil},
+		{"alns-solver", nil},
+		{"alns", []float64{0.1, 0.1}},
+		{"alns", []float64{0.1, 0.01}},
+	} {
+		if _, err := NewObserver(tc.namespace, tc.buckets); err == nil {
+			t.Errorf("an error expected for %q %v", tc.namespace, tc.buckets)
+		}
+	}
+}
+
+func TestObserverBeforeRun(t *testing.T) {
+	observer, err := NewObserver("solver", []float64{1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	recorder := httptest.NewRecorder()
+	observer.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body, _ := io.ReadAll(recorder.Result().Body)
+	expected := `# HELP solver_runs_total The number of started runs.
+# TYPE solver_runs_total counter
+solver_runs_total 0
+# HELP solver_running Whether a run is in progress.
+# TYPE solver_running gauge
+solver_running 0
+# HELP solver_iterations_total The number of finished iterations.
+# TYPE solver_iterations_total counter
+solver_iterations_total 0
+# HELP solver_best_objective The objective of the best solution of the last run.
+# TYPE solver_best_objective gauge
+solver_best_objective NaN
+# HELP solver_current_objective The objective of the current solution of the last run.
+# TYPE solver_current_objective gauge
+solver_current_objective NaN
+# HELP solver_operator_outcomes_total The outcomes of the iterations per operator.
+# TYPE solver_operator_outcomes_total counter
+# HELP solver_operator_weight The weights of the operators in the selection scheme.
+# TYPE solver_operator_weight gauge
+# HELP solver_iteration_duration_seconds The duration of the iterations.
+# TYPE solver_iteration_duration_seconds histogram
+solver_iteration_duration_seconds_bucket{le="1"} 0
+solver_iteration_duration_seconds_bucket{le="+Inf"} 0
+solver_iteration_duration_seconds_sum 0
+solver_iteration_duration_seconds_count 0
+`
+	if string(body) != expected {
+		t.Fatalf("the exposition\n%s\nexpected, actual\n%s", expected, body)
+	}
+}
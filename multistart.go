@@ -0,0 +1,190 @@
+package alns
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+	"time"
+)
+
+// RunSetup contains the components of a single run. They must not be shared between runs.
+type RunSetup struct {
+	InitialSolution  State
+	Selector         OperatorSelectionScheme
+	Acceptor         AcceptanceCriterion
+	Stop             StoppingCriterion
+	Restart          RestartStrategy // optional restart strategy, replaces ALNS.Restart
+	DestroyOperators []Operator      // optional destroy operators bound to Destruction, replace ALNS.DestroyOperators
+	Destruction      *Destruction    // optional degree of destruction, replaces ALNS.Destruction
+}
+
+// RunFactory creates the components of a run for the given seed.
+type RunFactory func(seed uint64) (RunSetup, error)
+
+type MultiStartResult struct {
+	Seeds          []uint64      // the seeds of the runs
+	Results        []*Result     // the results of the runs, in the order of the seeds
+	BestIndex      int           // the index of the run with the best solution
+	Best           float64       // the best objective
+	Mean           float64       // the mean of the best objectives
+	Median         float64       // the median of the best objectives
+	StdDev         float64       // the sample standard deviation of the best objectives
+	MeanTimeToBest time.Duration // the mean time at which the best solution of a run was found
+}
+
+// MultiStart runs the ALNS once for each seed and aggregates the results.
+//
+// Every run uses a copy of `a` with its own random streams derived from the seed (see SeedSequence) and
+// fresh copies of the pool, the cache, the penalty and the Pareto archive (if set). The restart strategy and
+// the degree of destruction keep a state that cannot be copied, they must be created by the factory
+// (see RunSetup) and an error is returned if they are set in `a`.
+// If workers is greater than 1, the runs are executed concurrently and the operators and
+// the remaining ALNS components must be safe for concurrent use.
+func MultiStart(a ALNS, factory RunFactory, seeds []uint64, workers int) (*MultiStartResult, error) {
+	if len(seeds) == 0 {
+		return nil, fmt.Errorf("no seeds were specified")
+	}
+	if err := checkShared(&a); err != nil {
+		return nil, err
+	}
+
+	results := make([]*Result, len(seeds))
+	errs := make([]error, len(seeds))
+	run := func(i int) {
+		results[i], errs[i] = runWithSeed(a, factory, seeds[i])
+	}
+
+	if workers <= 1 {
+		for i := range seeds {
+			run(i)
+			if errs[i] != nil {
+				break
+			}
+		}
+	} else {
+		indices := make(chan int)
+		var wg sync.WaitGroup
+		for range min(workers, len(seeds)) {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := range indices {
+					run(i)
+				}
+			}()
+		}
+		for i := range seeds {
+			indices <- i
+		}
+		close(indices)
+		wg.Wait()
+	}
+
+	for i, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("seed %d: %w", seeds[i], err)
+		}
+	}
+
+	return aggregate(slices.Clone(seeds), results), nil
+}
+
+func runWithSeed(a ALNS, factory RunFactory, seed uint64) (*Result, error) {
+	setup, err := factory(seed)
+	if err != nil {
+		return nil, err
+	}
+	a = seeded(a, setup, seed)
+	return a.Iterate(setup.InitialSolution, setup.Selector, setup.Acceptor, setup.Stop)
+}
+
+// checkShared returns an error if `a` contains a component with a state that cannot be copied for every run.
+func checkShared(a *ALNS) error {
+	if a.Restart != nil {
+		return fmt.Errorf("the restart strategy keeps a state, create it in the RunFactory (RunSetup.Restart)")
+	}
+	if a.Destruction != nil {
+		return fmt.Errorf("the destruction keeps a state, create it in the RunFactory (RunSetup.Destruction)")
+	}
+	return nil
+}
+
+// seeded returns the copy of `a` for the run of the seed, with fresh stateful components.
+func seeded(a ALNS, setup RunSetup, seed uint64) ALNS {
+	seeds := NewSeedSequence(seed)
+	a.Seeds = &seeds
+	if a.Pool != nil {
+		pool := NewSolutionPool(a.Pool.Size, a.Pool.MinDistance)
+		a.Pool = &pool
+	}
+	if a.Cache != nil {
+		cache := NewSolutionCache(a.Cache.Size, a.Cache.RejectDuplicates)
+		a.Cache = &cache
+	}
+	if a.Penalty != nil {
+		penalty := a.Penalty.fresh()
+		a.Penalty = &penalty
+	}
+	if a.Pareto != nil {
+		pareto := NewParetoArchive()
+		a.Pareto = &pareto
+	}
+	a.Restart = setup.Restart
+	a.Destruction = setup.Destruction
+	if setup.DestroyOperators != nil {
+		a.DestroyOperators = setup.DestroyOperators
+	}
+	return a
+}
+
+func aggregate(seeds []uint64, results []*Result) *MultiStartResult {
+	objectives := make([]float64, len(results))
+	var timeToBest time.Duration
+	bestIndex := 0
+	for i, result := range results {
+		objectives[i] = result.BestState.Objective()
+		if isBetterResult(result, results[bestIndex]) {
+			bestIndex = i
+		}
+		timeToBest += result.Statistics.BestRuntime
+	}
+
+	n := float64(len(objectives))
+	mean := sum(objectives) / n
+
+	stdDev := 0.0
+	if len(objectives) > 1 {
+		for _, objective := range objectives {
+			stdDev += (objective - mean) * (objective - mean)
+		}
+		stdDev = math.Sqrt(stdDev / (n - 1))
+	}
+
+	sorted := slices.Clone(objectives)
+	slices.Sort(sorted)
+	median := sorted[len(sorted)/2]
+	if len(sorted)%2 == 0 {
+		median = (sorted[len(sorted)/2-1] + median) / 2
+	}
+
+	return &MultiStartResult{
+		Seeds:          seeds,
+		Results:        results,
+		BestIndex:      bestIndex,
+		Best:           objectives[bestIndex],
+		Mean:           mean,
+		Median:         median,
+		StdDev:         stdDev,
+		MeanTimeToBest: timeToBest / time.Duration(len(results)),
+	}
+}
+
+// isBetterResult reports whether the best solution of the result is better than the best solution of
+// the other result, a feasible solution is better than an infeasible one (see Result.BestFeasibleState).
+func isBetterResult(result, other *Result) bool {
+	if feasible, otherFeasible := isFeasible(result.BestState), isFeasible(other.BestState); feasible != otherFeasible {
+		return feasible
+	}
+	return compareStates(result.BestState, other.BestState) < 0
+}
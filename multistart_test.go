@@ -0,0 +1,150 @@
+package alns
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestMultiStart(t *testing.T) {
+	a := ALNS{
+		DestroyOperators: []Operator{
+			func(state State, rnd *rand.Rand) (State, error) { return state.(*FakeState).Clone(), nil },
+		},
+		RepairOperators: []Operator{
+			func(state State, rnd *rand.Rand) (State, error) {
+				state.(*FakeState).objective = rnd.Float64()
+				return state, nil
+			},
+		},
+	}
+	factory := func(seed uint64) (RunSetup, error) {
+		selector, err := NewRouletteWheel([4]float64{3, 2, 1, 0.5}, 0.8, 1, 1, nil)
+		if err != nil {
+			return RunSetup{}, err
+		}
+		return RunSetup{
+			InitialSolution: &FakeState{objective: 1},
+			Selector:        &selector,
+			Acceptor:        &HillClimbing{},
+			Stop:            &MaxIterations{MaxIterations: 100},
+		}, nil
+	}
+	seeds := []uint64{1, 2, 3, 4, 5, 6, 7}
+
+	sequential, err := MultiStart(a, factory, seeds, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parallel, err := MultiStart(a, factory, seeds, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(sequential.Results) != len(seeds) {
+		t.Fatalf("%d results expected, actual %d", len(seeds), len(sequential.Results))
+	}
+	for i := range seeds {
+		s := sequential.Results[i].BestState.Objective()
+		p := parallel.Results[i].BestState.Objective()
+		if s != p {
+			t.Errorf("seed %d: the sequential run %f differs from the parallel run %f", seeds[i], s, p)
+		}
+	}
+	if sequential.Best != sequential.Results[sequential.BestIndex].BestState.Objective() {
+		t.Fatalf("the best objective %f does not match the best run", sequential.Best)
+	}
+	if !(sequential.Best <= sequential.Median && sequential.Best <= sequential.Mean) {
+		t.Fatalf("the best objective %f is greater than the median %f or the mean %f",
+			sequential.Best, sequential.Median, sequential.Mean)
+	}
+}
+
+func TestMultiStartAggregate(t *testing.T) {
+	objectives := []float64{4, 1, 3, 2}
+	results := make([]*Result, len(objectives))
+	for i, objective := range objectives {
+		results[i] = &Result{BestState: FakeState{objective: objective}}
+	}
+	res := aggregate([]uint64{1, 2, 3, 4}, results)
+	if res.BestIndex != 1 || res.Best != 1 {
+		t.Errorf("the best run 1 with objective 1 expected, actual %d with %f", res.BestIndex, res.Best)
+	}
+	if res.Mean != 2.5 {
+		t.Errorf("mean 2.5 expected, actual %f", res.Mean)
+	}
+	if res.Median != 2.5 {
+		t.Errorf("median 2.5 expected, actual %f", res.Median)
+	}
+	if math.Abs(res.StdDev-math.Sqrt(5.0/3.0)) > 1e-9 {
+		t.Errorf("standard deviation %f expected, actual %f", math.Sqrt(5.0/3.0), res.StdDev)
+	}
+
+	results = []*Result{
+		{BestState: &ConstrainedState{objective: 1, violation: 1}},
+		{BestState: &ConstrainedState{objective: 3}},
+		{BestState: &ConstrainedState{objective: 2}},
+	}
+	if res := aggregate([]uint64{1, 2, 3}, results); res.BestIndex != 2 || res.Best != 2 {
+		t.Errorf("the best feasible run 2 expected, actual %d with %f", res.BestIndex, res.Best)
+	}
+	results = []*Result{{BestState: LexState{1, 20}}, {BestState: LexState{1, 10}}}
+	if res := aggregate([]uint64{1, 2}, results); res.BestIndex != 1 {
+		t.Errorf("the run 1 better by Compare expected, actual %d", res.BestIndex)
+	}
+}
+
+func TestMultiStartRestart(t *testing.T) {
+	a := ALNS{
+		DestroyOperators: []Operator{
+			func(state State, rnd *rand.Rand) (State, error) { return state.(*FakeState).Clone(), nil },
+		},
+		RepairOperators: []Operator{
+			func(state State, rnd *rand.Rand) (State, error) {
+				state.(*FakeState).objective = rnd.Float64()
+				return state, nil
+			},
+		},
+	}
+	factory := func(seed uint64) (RunSetup, error) {
+		selector, err := NewRouletteWheel([4]float64{3, 2, 1, 0.5}, 0.8, 1, 1, nil)
+		if err != nil {
+			return RunSetup{}, err
+		}
+		restart := NewNoImprovementRestart(3)
+		return RunSetup{
+			InitialSolution: &FakeState{objective: 1},
+			Selector:        &selector,
+			Acceptor:        &HillClimbing{},
+			Stop:            &MaxIterations{MaxIterations: 100},
+			Restart:         &restart,
+		}, nil
+	}
+	seeds := []uint64{1, 2, 3, 1, 2, 3}
+
+	sequential, err := MultiStart(a, factory, seeds, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parallel, err := MultiStart(a, factory, seeds, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range seeds {
+		s, p := sequential.Results[i].Statistics.Restarts, parallel.Results[i].Statistics.Restarts
+		if len(s) == 0 || len(s) != len(p) {
+			t.Fatalf("seed %d: the sequential restarts %d differ from the parallel restarts %d", seeds[i], len(s), len(p))
+		}
+		for j := range s {
+			if s[j].Iteration != p[j].Iteration || s[j].Iteration != sequential.Results[i%3].Statistics.Restarts[j].Iteration {
+				t.Fatalf("seed %d: the restart %d differs between the runs of the seed", seeds[i], j)
+			}
+		}
+	}
+
+	restart := NewNoImprovementRestart(3)
+	a.Restart = &restart
+	if _, err := MultiStart(a, factory, seeds, 1); err == nil {
+		t.Fatal("an error expected for a restart strategy shared by the runs")
+	}
+}
@@ -0,0 +1,133 @@
+package alns
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// IterationEvent describes a finished iteration, see Observer.
+type IterationEvent struct {
+	Iteration int           // the iteration, see Statistics
+	Runtime   time.Duration // the time since the start
+	Destroy   int           // the index of the destroy operator
+	Repair    int           // the index of the repair operator
+	Candidate State         // the repaired (and improved) candidate
+	Current   State         // the current solution after the iteration, including a restart
+	Best      State         // the best solution after the iteration
+	Outcome   Outcome       // the outcome of the candidate
+	Accepted  bool          // the decision of the acceptance criterion, false for a rejected duplicate
+	alns      *ALNS
+	selector  OperatorSelectionScheme
+}
+
+// DestroyName returns the name of the destroy operator, see ALNS.DestroyOperatorNames.
+func (e *IterationEvent) DestroyName() string {
+	return e.alns.destroyOperatorName(e.Destroy)
+}
+
+// RepairName returns the name of the repair operator, see ALNS.RepairOperatorNames.
+func (e *IterationEvent) RepairName() string {
+	return e.alns.repairOperatorName(e.Repair)
+}
+
+// Weights returns the weights of the destroy and the repair operators after the iteration;
+// nil if the selection scheme is not a WeightedScheme.
+func (e *IterationEvent) Weights() (destroy, repair []float64) {
+	if weighted, ok := e.selector.(WeightedScheme); ok {
+		return weighted.Weights()
+	}
+	return nil, nil
+}
+
+// RandomState returns the hex encoded states of the random streams used in the iteration by their names
+// (e.g. "select" or "destroy/2"), taken after the iteration; nil if ALNS.Seeds is not set.
+func (e *IterationEvent) RandomState() map[string]string {
+	random := e.alns.streams()
+	names := []string{
+		SelectStream,
+		AcceptStream,
+		StopStream,
+		EngineStream,
+		streamName(DestroyStream, e.Destroy),
+		streamName(RepairStream, e.Repair),
+	}
+	for i := range random.localSearch {
+		names = append(names, streamName(LocalSearchStream, i))
+	}
+	return random.states(names...)
+}
+
+// Observer is notified after every iteration. The states of the event must not be modified,
+// an error stops the run.
+type Observer interface {
+	OnIteration(event *IterationEvent) error
+}
+
+// RunObserver is an Observer that is also notified about the start and the end of a run.
+type RunObserver interface {
+	Observer
+	OnStart(event *StartEvent) error
+	OnStop(event *StopEvent)
+}
+
+type StartEvent struct {
+	Initial          State    // the initial solution
+	Seed             *uint64  // the master seed; only if ALNS.Seeds is set
+	DestroyOperators []string // the names of the destroy operators
+	RepairOperators  []string // the names of the repair operators
+}
+
+type StopEvent struct {
+	Reason     string        // the stopping criterion that fired (e.g. MaxIterations) or "error"
+	Err        error         // the error that stopped the run
+	Iterations int           // the number of finished iterations
+	Runtime    time.Duration // the total runtime
+	Best       State         // the best solution found before the stop
+}
+
+func (a *ALNS) notifyStart(initSol State) error {
+	var event *StartEvent
+	for _, observer := range a.Observers {
+		if runObserver, ok := observer.(RunObserver); ok {
+			if event == nil {
+				event = &StartEvent{
+					Initial:          initSol,
+					DestroyOperators: make([]string, len(a.DestroyOperators)),
+					RepairOperators:  make([]string, len(a.RepairOperators)),
+				}
+				if a.Seeds != nil {
+					seed := a.Seeds.Master
+					event.Seed = &seed
+				}
+				for i := range event.DestroyOperators {
+					event.DestroyOperators[i] = a.destroyOperatorName(i)
+				}
+				for i := range event.RepairOperators {
+					event.RepairOperators[i] = a.repairOperatorName(i)
+				}
+			}
+			if err := runObserver.OnStart(event); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (a *ALNS) notifyStop(event *StopEvent) {
+	for _, observer := range a.Observers {
+		if runObserver, ok := observer.(RunObserver); ok {
+			runObserver.OnStop(event)
+		}
+	}
+}
+
+// stopReason describes the stopping criterion that fired, e.g. MaxIterations.
+func stopReason(stop StoppingCriterion) string {
+	if stop == nil {
+		return ""
+	}
+	name := fmt.Sprintf("%T", stop)
+	return name[strings.LastIndex(name, ".")+1:]
+}
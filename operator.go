@@ -1,5 +1,26 @@
 package alns
 
-import "math/rand/v2"
+import (
+	"fmt"
+	"math/rand/v2"
+)
 
 type Operator func(state State, rnd *rand.Rand) (State, error)
+
+func (a *ALNS) destroyOperatorName(idx int) string {
+	if idx < len(a.DestroyOperatorNames) {
+		return a.DestroyOperatorNames[idx]
+	}
+	return fmt.Sprintf("destroy operator %d", idx)
+}
+
+func (a *ALNS) repairOperatorName(idx int) string {
+	if idx < len(a.RepairOperatorNames) {
+		return a.RepairOperatorNames[idx]
+	}
+	return fmt.Sprintf("repair operator %d", idx)
+}
+
+func localSearchName(idx int) string {
+	return fmt.Sprintf("local search %d", idx)
+}
@@ -27,3 +27,20 @@ func (o Outcome) String() string {
 		return fmt.Sprintf("%%!Outcome(%d)", o)
 	}
 }
+
+func (o Outcome) MarshalText() ([]byte, error) {
+	if o < Best || o > Reject {
+		return nil, fmt.Errorf("unknown outcome %d", int(o))
+	}
+	return []byte(o.String()), nil
+}
+
+func (o *Outcome) UnmarshalText(text []byte) error {
+	for _, outcome := range []Outcome{Best, Better, Accept, Reject} {
+		if string(text) == outcome.String() {
+			*o = outcome
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown outcome %q", text)
+}
@@ -0,0 +1,76 @@
+package perm
+
+import (
+	"math"
+	"math/rand/v2"
+
+	"github.com/bibenga/alns"
+)
+
+// RemovalGain returns the improvement of the objective if the element at the position pos is
+// removed from the sequence.
+type RemovalGain[E comparable] func(sequence []E, pos int) float64
+
+// elementsToRemove returns the number of elements removed by the destroy operators for the degree of destruction.
+func elementsToRemove[E comparable](state *PermutationState[E], degree float64) int {
+	return min(max(int(float64(state.Len())*degree), 1), state.Len())
+}
+
+// RandomRemoval removes random elements.
+func RandomRemoval[E comparable](degree float64) alns.Operator {
+	return func(state alns.State, rnd *rand.Rand) (alns.State, error) {
+		destroyed := state.(*PermutationState[E]).Clone()
+		for range elementsToRemove(destroyed, degree) {
+			destroyed.Remove(rnd.IntN(destroyed.Len()))
+		}
+		return destroyed, nil
+	}
+}
+
+// AdjacentRemoval removes a block of consecutive elements starting at a random position.
+func AdjacentRemoval[E comparable](degree float64) alns.Operator {
+	return func(state alns.State, rnd *rand.Rand) (alns.State, error) {
+		destroyed := state.(*PermutationState[E]).Clone()
+		count := elementsToRemove(destroyed, degree)
+		start := rnd.IntN(destroyed.Len() - count + 1)
+		for range count {
+			destroyed.Remove(start)
+		}
+		return destroyed, nil
+	}
+}
+
+// SegmentRemoval removes the segment between two random cut points, the length of the segment
+// is uniformly distributed between 1 and the number given by the degree of destruction.
+func SegmentRemoval[E comparable](degree float64) alns.Operator {
+	return func(state alns.State, rnd *rand.Rand) (alns.State, error) {
+		destroyed := state.(*PermutationState[E]).Clone()
+		count := elementsToRemove(destroyed, degree)
+		if count == 0 {
+			return destroyed, nil
+		}
+		length := 1 + rnd.IntN(count)
+		start := rnd.IntN(destroyed.Len() - length + 1)
+		for range length {
+			destroyed.Remove(start)
+		}
+		return destroyed, nil
+	}
+}
+
+// WorstRemoval removes one by one the elements with the largest removal gain.
+func WorstRemoval[E comparable](degree float64, gain RemovalGain[E]) alns.Operator {
+	return func(state alns.State, rnd *rand.Rand) (alns.State, error) {
+		destroyed := state.(*PermutationState[E]).Clone()
+		for range elementsToRemove(destroyed, degree) {
+			worst, worstGain := 0, math.Inf(-1)
+			for pos := range destroyed.Len() {
+				if g := gain(destroyed.Sequence(), pos); g > worstGain {
+					worst, worstGain = pos, g
+				}
+			}
+			destroyed.Remove(worst)
+		}
+		return destroyed, nil
+	}
+}
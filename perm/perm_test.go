@@ -0,0 +1,325 @@
+package perm
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/bibenga/alns"
+)
+
+// circle is a closed tour over n points on a circle, the optimal tour visits them in order.
+type circle struct {
+	dists [][]float64
+}
+
+func newCircle(n int) *circle {
+	c := circle{dists: make([][]float64, n)}
+	for i := range n {
+		c.dists[i] = make([]float64, n)
+		for j := range n {
+			a, b := 2*math.Pi*float64(i)/float64(n), 2*math.Pi*float64(j)/float64(n)
+			c.dists[i][j] = math.Hypot(math.Cos(a)-math.Cos(b), math.Sin(a)-math.Sin(b))
+		}
+	}
+	return &c
+}
+
+func (c *circle) optimum() float64 {
+	n := float64(len(c.dists))
+	return 2 * n * math.Sin(math.Pi/n)
+}
+
+func (c *circle) length(sequence []int) float64 {
+	v := 0.0
+	for i, node := range sequence {
+		v += c.dists[node][sequence[(i+1)%len(sequence)]]
+	}
+	return v
+}
+
+// neighbours returns the nodes before and after the position pos of the closed tour.
+func neighbours(sequence []int, pos int) (int, int) {
+	n := len(sequence)
+	return sequence[(pos-1+n)%n], sequence[pos%n]
+}
+
+func (c *circle) insertionCost(sequence []int, node int, pos int) float64 {
+	if len(sequence) == 0 {
+		return 0
+	}
+	prev, next := neighbours(sequence, pos)
+	return c.dists[prev][node] + c.dists[node][next] - c.dists[prev][next]
+}
+
+func (c *circle) removalGain(sequence []int, pos int) float64 {
+	node := sequence[pos]
+	prev, _ := neighbours(sequence, pos)
+	_, next := neighbours(sequence, pos+1)
+	return c.dists[prev][node] + c.dists[node][next] - c.dists[prev][next]
+}
+
+func checkState(t *testing.T, s *PermutationState[int], n int) {
+	t.Helper()
+	elements := append(slices.Clone(s.Sequence()), s.Removed()...)
+	slices.Sort(elements)
+	if len(elements) != n {
+		t.Fatalf("%d elements expected, actual %d", n, len(elements))
+	}
+	for i, element := range elements {
+		if i != element {
+			t.Fatalf("the sequence %v and the removed elements %v are not a permutation", s.Sequence(), s.Removed())
+		}
+	}
+}
+
+func TestPermutationState(t *testing.T) {
+	c := newCircle(4)
+	s := NewPermutationState([]int{0, 2, 1, 3}, c.length)
+	if math.Abs(s.Objective()-(4+2*math.Sqrt2)) > 1e-9 {
+		t.Fatalf("the objective %f expected, actual %f", 4+2*math.Sqrt2, s.Objective())
+	}
+	clone := s.Clone()
+	clone.Remove(1)
+	clone.Insert(2, 2)
+	if !slices.Equal(clone.Sequence(), []int{0, 1, 2, 3}) || len(clone.Removed()) != 0 {
+		t.Fatalf("the sequence [0 1 2 3] expected, actual %v and removed %v", clone.Sequence(), clone.Removed())
+	}
+	if math.Abs(clone.Objective()-c.optimum()) > 1e-9 {
+		t.Fatalf("the objective %f expected, actual %f", c.optimum(), clone.Objective())
+	}
+	if !slices.Equal(s.Sequence(), []int{0, 2, 1, 3}) {
+		t.Fatalf("the original state was mutated: %v", s.Sequence())
+	}
+}
+
+// isSubsequence reports whether the elements of sub appear in sequence in the same order.
+func isSubsequence[E comparable](sub, sequence []E) bool {
+	i := 0
+	for _, element := range sequence {
+		if i < len(sub) && sub[i] == element {
+			i++
+		}
+	}
+	return i == len(sub)
+}
+
+func TestDestroy(t *testing.T) {
+	const n = 20
+	c := newCircle(n)
+	rnd := rand.New(rand.NewPCG(1, 2))
+	initial := NewPermutationState(rnd.Perm(n), c.length)
+	sequence := slices.Clone(initial.Sequence())
+
+	tests := map[string]struct {
+		destroyOp  alns.Operator
+		exact      bool // exactly the degree of the elements are removed, otherwise 1 to the degree
+		contiguous bool // the removed elements are consecutive in the sequence
+	}{
+		"RandomRemoval":   {RandomRemoval[int](0.25), true, false},
+		"AdjacentRemoval": {AdjacentRemoval[int](0.25), true, true},
+		"SegmentRemoval":  {SegmentRemoval[int](0.25), false, true},
+		"WorstRemoval":    {WorstRemoval(0.25, c.removalGain), true, false},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			for range 20 {
+				destroyed, err := tt.destroyOp(initial, rnd)
+				if err != nil {
+					t.Fatal(err)
+				}
+				s := destroyed.(*PermutationState[int])
+				checkState(t, s, n)
+				if removed := len(s.Removed()); removed < 1 || removed > 5 || (tt.exact && removed != 5) {
+					t.Fatalf("5 removed elements expected, actual %d", removed)
+				}
+				if !isSubsequence(s.Sequence(), sequence) {
+					t.Fatalf("the order of %v is not kept in %v", sequence, s.Sequence())
+				}
+				if tt.contiguous {
+					positions := make([]int, 0, len(s.Removed()))
+					for _, element := range s.Removed() {
+						positions = append(positions, slices.Index(sequence, element))
+					}
+					if slices.Max(positions)-slices.Min(positions)+1 != len(positions) {
+						t.Fatalf("the removed elements %v of %v are not consecutive", s.Removed(), sequence)
+					}
+				}
+				if math.Abs(s.Objective()-c.length(s.Sequence())) > 1e-9 {
+					t.Fatal("the cached objective is stale")
+				}
+			}
+			if !slices.Equal(initial.Sequence(), sequence) {
+				t.Fatal("the input state was mutated")
+			}
+		})
+	}
+}
+
+func TestRepair(t *testing.T) {
+	const n = 20
+	c := newCircle(n)
+	rnd := rand.New(rand.NewPCG(1, 2))
+	initial := NewPermutationState(rnd.Perm(n), c.length)
+
+	repairOperators := map[string]alns.Operator{
+		"RandomInsertion":   RandomInsertion[int](),
+		"CheapestInsertion": CheapestInsertion(c.insertionCost),
+		"RegretInsertion":   RegretInsertion(3, c.insertionCost),
+	}
+	for name, repairOp := range repairOperators {
+		t.Run(name, func(t *testing.T) {
+			// the assigned elements keep their order
+			destroyed, err := RandomRemoval[int](0.25)(initial, rnd)
+			if err != nil {
+				t.Fatal(err)
+			}
+			kept := slices.Clone(destroyed.(*PermutationState[int]).Sequence())
+			repaired, err := repairOp(destroyed, rnd)
+			if err != nil {
+				t.Fatal(err)
+			}
+			s := repaired.(*PermutationState[int])
+			checkState(t, s, n)
+			if len(s.Removed()) != 0 || !isSubsequence(kept, s.Sequence()) {
+				t.Fatalf("all elements inserted around %v expected, actual %v", kept, s.Sequence())
+			}
+			if math.Abs(s.Objective()-c.length(s.Sequence())) > 1e-9 {
+				t.Fatal("the cached objective is stale")
+			}
+		})
+	}
+
+	// a single element is inserted at its cheapest position
+	for name, repairOp := range map[string]alns.Operator{
+		"CheapestInsertion": repairOperators["CheapestInsertion"],
+		"RegretInsertion":   repairOperators["RegretInsertion"],
+	} {
+		t.Run(name+"/Single", func(t *testing.T) {
+			for pos := range n {
+				s := initial.Clone()
+				s.Remove(pos)
+				element := s.Removed()[0]
+				cheapest := math.Inf(1)
+				for p := range s.Len() + 1 {
+					cheapest = min(cheapest, c.insertionCost(s.Sequence(), element, p))
+				}
+				objective := s.Objective()
+				repaired, err := repairOp(s, nil)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got := repaired.Objective(); math.Abs(got-objective-cheapest) > 1e-9 {
+					t.Fatalf("the element %d: the objective %f expected, actual %f", element, objective+cheapest, got)
+				}
+			}
+		})
+	}
+}
+
+func TestRegretOrder(t *testing.T) {
+	// the element a is cheaper, but b has the larger regret
+	costs := map[string][]float64{"a": {1, 2, 3}, "b": {1.5, 9, 9}}
+	cost := func(sequence []string, element string, pos int) float64 { return costs[element][pos] }
+	tests := map[string]struct {
+		repairOp alns.Operator
+		expected []string
+	}{
+		"CheapestInsertion": {CheapestInsertion(cost), []string{"b", "a", "x"}},
+		"RegretInsertion":   {RegretInsertion(2, cost), []string{"a", "b", "x"}},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			s := NewPermutationState([]string{"a", "b", "x"}, func([]string) float64 { return 0 })
+			s.Remove(0)
+			s.Remove(0)
+			repaired, err := tt.repairOp(s, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if sequence := repaired.(*PermutationState[string]).Sequence(); !slices.Equal(sequence, tt.expected) {
+				t.Fatalf("%v expected, actual %v", tt.expected, sequence)
+			}
+		})
+	}
+}
+
+func TestWorstRemoval(t *testing.T) {
+	c := newCircle(8)
+	// the nodes 4 and 5 are swapped, removing one of them has the largest gain
+	s := NewPermutationState([]int{0, 1, 2, 3, 5, 4, 6, 7}, c.length)
+	destroyed, err := WorstRemoval(0.1, c.removalGain)(s, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if removed := destroyed.(*PermutationState[int]).Removed(); !slices.Equal(removed, []int{5}) && !slices.Equal(removed, []int{4}) {
+		t.Fatalf("the node 4 or 5 expected to be removed, actual %v", removed)
+	}
+	repaired, err := CheapestInsertion(c.insertionCost)(destroyed, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(repaired.Objective()-c.optimum()) > 1e-9 {
+		t.Fatalf("the optimal tour expected, actual %v", repaired.(*PermutationState[int]).Sequence())
+	}
+}
+
+func TestInsertionWithoutPosition(t *testing.T) {
+	c := newCircle(4)
+	infeasible := func(sequence []int, node int, pos int) float64 {
+		if node == 3 {
+			return math.NaN()
+		}
+		return math.Inf(1)
+	}
+	for name, repairOp := range map[string]alns.Operator{
+		"CheapestInsertion": CheapestInsertion(infeasible),
+		"RegretInsertion":   RegretInsertion(2, infeasible),
+	} {
+		t.Run(name, func(t *testing.T) {
+			destroyed, err := RandomRemoval[int](0.5)(NewPermutationState([]int{0, 1, 2, 3}, c.length), rand.New(rand.NewPCG(1, 2)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := repairOp(destroyed, nil); err == nil {
+				t.Fatal("an error expected if no insertion cost is finite")
+			}
+		})
+	}
+}
+
+func TestSolve(t *testing.T) {
+	const n = 30
+	c := newCircle(n)
+	rnd := rand.New(rand.NewPCG(12, 34))
+	initial := NewPermutationState(rnd.Perm(n), c.length)
+
+	a := alns.ALNS{
+		Rnd: rnd,
+		DestroyOperators: []alns.Operator{
+			RandomRemoval[int](0.2),
+			AdjacentRemoval[int](0.2),
+			SegmentRemoval[int](0.2),
+			WorstRemoval(0.2, c.removalGain),
+		},
+		RepairOperators: []alns.Operator{
+			RandomInsertion[int](),
+			CheapestInsertion(c.insertionCost),
+			RegretInsertion(2, c.insertionCost),
+		},
+	}
+	selector, err := alns.NewRouletteWheel([4]float64{3, 2, 1, 0.5}, 0.8, 4, 3, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	accept := alns.HillClimbing{}
+	stop := alns.MaxIterations{MaxIterations: 500}
+	result, err := a.Iterate(initial, &selector, &accept, &stop)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(result.BestState.Objective()-c.optimum()) > 1e-9 {
+		t.Fatalf("the optimum %f expected, actual %f", c.optimum(), result.BestState.Objective())
+	}
+}
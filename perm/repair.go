@@ -0,0 +1,93 @@
+package perm
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+
+	"github.com/bibenga/alns"
+)
+
+// InsertionCost returns the change of the objective if the element is inserted at the position
+// pos (0..len(sequence)) of the sequence.
+type InsertionCost[E comparable] func(sequence []E, element E, pos int) float64
+
+// RandomInsertion inserts the removed elements in random order at random positions.
+func RandomInsertion[E comparable]() alns.Operator {
+	return func(state alns.State, rnd *rand.Rand) (alns.State, error) {
+		repaired := state.(*PermutationState[E])
+		for len(repaired.Removed()) > 0 {
+			element := repaired.Removed()[rnd.IntN(len(repaired.Removed()))]
+			repaired.Insert(element, rnd.IntN(repaired.Len()+1))
+		}
+		return repaired, nil
+	}
+}
+
+// CheapestInsertion repeatedly inserts the removed element with the cheapest insertion at its
+// cheapest position. It returns an error if no removed element has a finite insertion cost.
+func CheapestInsertion[E comparable](cost InsertionCost[E]) alns.Operator {
+	return func(state alns.State, rnd *rand.Rand) (alns.State, error) {
+		repaired := state.(*PermutationState[E])
+		for len(repaired.Removed()) > 0 {
+			var bestElement E
+			bestPos, bestCost := 0, math.Inf(1)
+			for _, element := range repaired.Removed() {
+				for pos := range repaired.Len() + 1 {
+					if c := cost(repaired.Sequence(), element, pos); c < bestCost {
+						bestElement, bestPos, bestCost = element, pos, c
+					}
+				}
+			}
+			if math.IsInf(bestCost, 1) {
+				return nil, fmt.Errorf("cheapest insertion: no insertion position for the elements %v", repaired.Removed())
+			}
+			repaired.Insert(bestElement, bestPos)
+		}
+		return repaired, nil
+	}
+}
+
+// RegretInsertion repeatedly inserts the removed element with the largest regret-k value at its
+// cheapest position. The regret is the sum of the differences between the cost of the cheapest
+// position and the costs of the next k-1 cheapest positions, k is at least 2. It returns an error
+// if a removed element has no finite insertion cost.
+func RegretInsertion[E comparable](k int, cost InsertionCost[E]) alns.Operator {
+	k = max(k, 2)
+	return func(state alns.State, rnd *rand.Rand) (alns.State, error) {
+		repaired := state.(*PermutationState[E])
+		costs := make([]float64, 0, repaired.Len()+len(repaired.Removed()))
+		for len(repaired.Removed()) > 0 {
+			var bestElement E
+			bestPos, bestRegret := 0, math.Inf(-1)
+			for _, element := range repaired.Removed() {
+				costs = costs[:0]
+				pos, cheapest := 0, math.Inf(1)
+				for p := range repaired.Len() + 1 {
+					c := cost(repaired.Sequence(), element, p)
+					if math.IsNaN(c) {
+						continue
+					}
+					costs = append(costs, c)
+					if c < cheapest {
+						pos, cheapest = p, c
+					}
+				}
+				if math.IsInf(cheapest, 1) {
+					return nil, fmt.Errorf("regret insertion: no insertion position for the element %v", element)
+				}
+				slices.Sort(costs)
+				regret := 0.0
+				for _, c := range costs[1:min(k, len(costs))] {
+					regret += c - cheapest
+				}
+				if regret > bestRegret {
+					bestElement, bestPos, bestRegret = element, pos, regret
+				}
+			}
+			repaired.Insert(bestElement, bestPos)
+		}
+		return repaired, nil
+	}
+}
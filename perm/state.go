@@ -0,0 +1,86 @@
+// Package perm provides a state and ready-made operators for problems whose solutions are
+// permutations, e.g. sequencing, assignment and tours.
+//
+// The operators work on *PermutationState[E] and have the alns.Operator signature, the problem
+// specific parts are supplied as callbacks: the objective of the state, the cost of an insertion
+// and the gain of a removal.
+package perm
+
+import (
+	"math"
+	"slices"
+
+	"github.com/bibenga/alns"
+)
+
+// ObjectiveFunc evaluates a (possibly partial) sequence.
+type ObjectiveFunc[E comparable] func(sequence []E) float64
+
+// PermutationState is a sequence of the assigned elements and a list of the removed elements
+// that the repair operators insert back. The objective is evaluated by the callback and cached
+// until the sequence changes.
+type PermutationState[E comparable] struct {
+	sequence  []E
+	removed   []E
+	objective ObjectiveFunc[E]
+	value     float64 // the cached objective or NaN
+}
+
+var _ alns.State = &PermutationState[int]{}
+
+// NewPermutationState creates a state with all elements assigned in the given order.
+func NewPermutationState[E comparable](sequence []E, objective ObjectiveFunc[E]) *PermutationState[E] {
+	return &PermutationState[E]{
+		sequence:  slices.Clone(sequence),
+		objective: objective,
+		value:     math.NaN(),
+	}
+}
+
+func (s *PermutationState[E]) Clone() *PermutationState[E] {
+	return &PermutationState[E]{
+		sequence:  slices.Clone(s.sequence),
+		removed:   slices.Clone(s.removed),
+		objective: s.objective,
+		value:     s.value,
+	}
+}
+
+// Objective returns the objective of the sequence of the assigned elements.
+func (s *PermutationState[E]) Objective() float64 {
+	if math.IsNaN(s.value) {
+		s.value = s.objective(s.sequence)
+	}
+	return s.value
+}
+
+// Sequence returns the assigned elements in order, the slice must not be modified.
+func (s *PermutationState[E]) Sequence() []E {
+	return s.sequence
+}
+
+// Removed returns the unassigned elements, the slice must not be modified.
+func (s *PermutationState[E]) Removed() []E {
+	return s.removed
+}
+
+// Len returns the number of assigned elements.
+func (s *PermutationState[E]) Len() int {
+	return len(s.sequence)
+}
+
+// Remove unassigns the element at the position pos.
+func (s *PermutationState[E]) Remove(pos int) {
+	s.removed = append(s.removed, s.sequence[pos])
+	s.sequence = slices.Delete(s.sequence, pos, pos+1)
+	s.value = math.NaN()
+}
+
+// Insert assigns the removed element at the position pos (0..Len()).
+func (s *PermutationState[E]) Insert(element E, pos int) {
+	if i := slices.Index(s.removed, element); i >= 0 {
+		s.removed = slices.Delete(s.removed, i, i+1)
+	}
+	s.sequence = slices.Insert(s.sequence, pos, element)
+	s.value = math.NaN()
+}
@@ -0,0 +1,103 @@
+package alns
+
+import (
+	"math/rand/v2"
+	"slices"
+)
+
+// Hasher is an optional extension of State. States with equal hashes are considered identical.
+type Hasher interface {
+	Hash() uint64
+}
+
+// Distance is an optional extension of State that measures how different two solutions are.
+type Distance interface {
+	Distance(other State) float64
+}
+
+// The `SolutionPool` keeps the best distinct solutions found during the search (an elite archive).
+//
+// Two solutions are considered the same when their hashes are equal (see Hasher) or when
+// the distance between them is less than MinDistance (see Distance). If a state implements
+// neither interface, all solutions are treated as distinct. The solutions are ranked like the best
+// solution (see Comparable) and infeasible solutions (see Feasibility) are never added.
+type SolutionPool struct {
+	Size        int     // the maximum number of solutions in the pool
+	MinDistance float64 // the minimum distance between two distinct solutions
+	states      []State // the best solution first
+}
+
+func NewSolutionPool(size int, minDistance float64) SolutionPool {
+	return SolutionPool{
+		Size:        size,
+		MinDistance: minDistance,
+	}
+}
+
+// Add tries to add the state to the pool and reports whether the pool has been changed.
+func (p *SolutionPool) Add(state State) bool {
+	if p.Size <= 0 || !isFeasible(state) {
+		return false
+	}
+
+	if idx := p.indexOfDuplicate(state); idx >= 0 {
+		if compareStates(state, p.states[idx]) >= 0 {
+			return false
+		}
+		// the new solution is better than its twin
+		p.states = slices.Delete(p.states, idx, idx+1)
+	} else if len(p.states) >= p.Size {
+		if compareStates(state, p.states[len(p.states)-1]) >= 0 {
+			return false
+		}
+		p.states = p.states[:len(p.states)-1]
+	}
+
+	idx, _ := slices.BinarySearchFunc(p.states, state, func(s, state State) int {
+		if compareStates(s, state) <= 0 {
+			return -1
+		}
+		return 1
+	})
+	p.states = slices.Insert(p.states, idx, state)
+	return true
+}
+
+func (p *SolutionPool) indexOfDuplicate(state State) int {
+	for i, other := range p.states {
+		if isSameSolution(p.MinDistance, state, other) {
+			return i
+		}
+	}
+	return -1
+}
+
+func isSameSolution(minDistance float64, a, b State) bool {
+	if ha, ok := a.(Hasher); ok {
+		if hb, ok := b.(Hasher); ok {
+			return ha.Hash() == hb.Hash()
+		}
+	}
+	if da, ok := a.(Distance); ok {
+		return da.Distance(b) < minDistance
+	}
+	return false
+}
+
+// Len returns the number of solutions in the pool.
+func (p *SolutionPool) Len() int {
+	return len(p.states)
+}
+
+// States returns the solutions in the pool, the best solution first.
+func (p *SolutionPool) States() []State {
+	return slices.Clone(p.states)
+}
+
+// Random returns a random solution from the pool or nil if the pool is empty.
+func (p *SolutionPool) Random(rnd *rand.Rand) State {
+	if len(p.states) == 0 {
+		return nil
+	}
+	return p.states[rnd.IntN(len(p.states))]
+}
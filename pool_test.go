@@ -0,0 +1,143 @@
+package alns
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+)
+
+type HashedState struct {
+	objective float64
+	hash      uint64
+}
+
+func (s *HashedState) Objective() float64 {
+	return s.objective
+}
+
+func (s *HashedState) Hash() uint64 {
+	return s.hash
+}
+
+type PointState struct {
+	objective float64
+}
+
+func (s *PointState) Objective() float64 {
+	return s.objective
+}
+
+func (s *PointState) Distance(other State) float64 {
+	return math.Abs(s.objective - other.(*PointState).objective)
+}
+
+func TestSolutionPool(t *testing.T) {
+	t.Run("Simple", func(t *testing.T) {
+		pool := NewSolutionPool(3, 0)
+		for _, objective := range []float64{5, 3, 4, 1, 2, 6} {
+			pool.Add(&FakeState{objective: objective})
+		}
+		if pool.Len() != 3 {
+			t.Fatalf("3 solutions expected, actual %d", pool.Len())
+		}
+		for i, state := range pool.States() {
+			if state.Objective() != float64(i+1) {
+				t.Errorf("solution %d: objective %f expected, actual %f", i, float64(i+1), state.Objective())
+			}
+		}
+	})
+
+	t.Run("Hasher", func(t *testing.T) {
+		pool := NewSolutionPool(3, 0)
+		if !pool.Add(&HashedState{objective: 2, hash: 1}) {
+			t.Fatal("expected to be added")
+		}
+		if pool.Add(&HashedState{objective: 3, hash: 1}) {
+			t.Fatal("expected not to be added, it is a worse duplicate")
+		}
+		if !pool.Add(&HashedState{objective: 1, hash: 1}) {
+			t.Fatal("expected to be added, it is a better duplicate")
+		}
+		if !pool.Add(&HashedState{objective: 3, hash: 2}) {
+			t.Fatal("expected to be added")
+		}
+		if pool.Len() != 2 {
+			t.Fatalf("2 solutions expected, actual %d", pool.Len())
+		}
+		if pool.States()[0].Objective() != 1 {
+			t.Fatalf("objective 1 expected, actual %f", pool.States()[0].Objective())
+		}
+	})
+
+	t.Run("Distance", func(t *testing.T) {
+		pool := NewSolutionPool(10, 1)
+		for _, objective := range []float64{1, 1.5, 2, 2.5, 3} {
+			pool.Add(&PointState{objective: objective})
+		}
+		if pool.Len() != 3 {
+			t.Fatalf("3 solutions expected, actual %d", pool.Len())
+		}
+	})
+
+	t.Run("Feasibility", func(t *testing.T) {
+		pool := NewSolutionPool(3, 0)
+		if pool.Add(&ConstrainedState{objective: 1, violation: 1}) {
+			t.Fatal("an infeasible solution expected not to be added")
+		}
+		if !pool.Add(&ConstrainedState{objective: 2}) || pool.Len() != 1 {
+			t.Fatal("a feasible solution expected to be added")
+		}
+	})
+
+	t.Run("Comparable", func(t *testing.T) {
+		pool := NewSolutionPool(2, 0)
+		for _, state := range []LexState{{1, 30}, {1, 10}, {1, 20}} {
+			pool.Add(state)
+		}
+		if states := pool.States(); states[0] != (LexState{1, 10}) || states[1] != (LexState{1, 20}) {
+			t.Fatalf("the solutions ordered by Compare expected, actual %v", states)
+		}
+	})
+
+	t.Run("Random", func(t *testing.T) {
+		r := rand.New(rand.NewPCG(1, 3))
+		pool := NewSolutionPool(3, 0)
+		if pool.Random(r) != nil {
+			t.Fatal("empty pool must return nil")
+		}
+		pool.Add(&FakeState{objective: 1})
+		if pool.Random(r) == nil {
+			t.Fatal("solution expected")
+		}
+	})
+}
+
+func TestAlnsPool(t *testing.T) {
+	opSelect, _ := NewRouletteWheel([4]float64{3, 2, 1, 0.5}, 0.8, 1, 1, nil)
+	accept := HillClimbing{}
+	stop := MaxIterations{MaxIterations: 100}
+	pool := NewSolutionPool(5, 0)
+	a := ALNS{
+		Rnd: rand.New(rand.NewPCG(1, 2)),
+		DestroyOperators: []Operator{
+			func(state State, rnd *rand.Rand) (State, error) { return state.(*FakeState).Clone(), nil },
+		},
+		RepairOperators: []Operator{
+			func(state State, rnd *rand.Rand) (State, error) {
+				state.(*FakeState).objective = rnd.Float64()
+				return state, nil
+			},
+		},
+		Pool: &pool,
+	}
+	res, err := a.Iterate(&FakeState{objective: 1}, &opSelect, &accept, &stop)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Pool) != 5 {
+		t.Fatalf("5 solutions expected, actual %d", len(res.Pool))
+	}
+	if res.Pool[0].Objective() != res.BestState.Objective() {
+		t.Fatalf("the best solution %f expected first, actual %f", res.BestState.Objective(), res.Pool[0].Objective())
+	}
+}
@@ -0,0 +1,374 @@
+package cvrp
+
+import (
+	"bytes"
+	"math"
+	"math/rand/v2"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/bibenga/alns"
+)
+
+const toyOptimal = 453
+
+func loadInstance(t testing.TB) *Instance {
+	instance, err := ReadFile("testdata/toy-n11-k3.vrp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return instance
+}
+
+func initialState(t testing.TB, instance *Instance, rnd *rand.Rand) *State {
+	state, err := GreedyRepair(0)(NewState(instance), rnd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return state.(*State)
+}
+
+func loadOptimal(t *testing.T, instance *Instance) *State {
+	t.Helper()
+	solution, err := ReadSolutionFile("testdata/toy-n11-k3.sol")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := NewStateFromSolution(instance, solution)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// checkState recomputes the loads and the costs from scratch.
+func checkState(t *testing.T, s *State) {
+	t.Helper()
+	d := s.instance.Dists
+	visited := len(s.Unassigned())
+	total := 0.0
+	for r := range s.NumRoutes() {
+		customers := s.Route(r)
+		if len(customers) == 0 {
+			t.Fatalf("the route %d is empty", r)
+		}
+		load, cost, prev := 0, 0.0, Depot
+		for _, customer := range customers {
+			if s.RouteOf(customer) != r {
+				t.Fatalf("the customer %d is expected in the route %d, actual %d", customer, r, s.RouteOf(customer))
+			}
+			load += s.instance.Demands[customer]
+			cost += d[prev][customer]
+			prev = customer
+		}
+		cost += d[prev][Depot]
+		visited += len(customers)
+		total += cost
+		if load != s.Load(r) || load > s.instance.Capacity {
+			t.Fatalf("the route %d: the cached load %d, actual %d, capacity %d", r, s.Load(r), load, s.instance.Capacity)
+		}
+		if math.Abs(cost-s.Cost(r)) > 1e-6 {
+			t.Fatalf("the route %d: the cached cost %f, actual %f", r, s.Cost(r), cost)
+		}
+	}
+	if visited != s.instance.Dimension-1 {
+		t.Fatalf("%d customers expected, actual %d", s.instance.Dimension-1, visited)
+	}
+	if math.Abs(total-s.Objective()) > 1e-6 {
+		t.Fatalf("the cached objective %f differs from the total cost %f", s.Objective(), total)
+	}
+}
+
+func TestParse(t *testing.T) {
+	instance := loadInstance(t)
+	if instance.Name != "toy-n11-k3" || instance.Dimension != 11 || instance.Capacity != 12 {
+		t.Fatalf("unexpected instance %q, dimension %d, capacity %d", instance.Name, instance.Dimension, instance.Capacity)
+	}
+	if instance.Demands[Depot] != 0 || instance.Demands[6] != 6 || instance.Coords[10] != [2]float64{31, 48} {
+		t.Fatalf("unexpected demands %v or coordinates %v", instance.Demands, instance.Coords)
+	}
+	if instance.Dists[0][1] != 34 {
+		t.Fatalf("the distance 34 expected, actual %f", instance.Dists[0][1])
+	}
+	if neighbours := instance.Neighbours(Depot); len(neighbours) != 10 || neighbours[0] != 9 {
+		t.Fatalf("the nearest customer 9 expected, actual %v", neighbours)
+	}
+
+	invalid := map[string]string{
+		"type":     "TYPE : TSP\n",
+		"capacity": "CAPACITY : 0\n",
+		"demand":   "DEMAND_SECTION\n1 0\n2 13\n",
+		"depot":    "DEPOT_SECTION\n2\n-1\n",
+	}
+	for name, header := range invalid {
+		t.Run(name, func(t *testing.T) {
+			data := "NAME : invalid\nTYPE : CVRP\nDIMENSION : 2\nEDGE_WEIGHT_TYPE : EUC_2D\nCAPACITY : 10\n" +
+				"NODE_COORD_SECTION\n1 0 0\n2 3 4\nDEMAND_SECTION\n1 0\n2 1\nDEPOT_SECTION\n1\n-1\n" +
+				header + "EOF\n"
+			if _, err := Parse(strings.NewReader(data)); err == nil {
+				t.Fatal("an error expected")
+			}
+		})
+	}
+}
+
+func TestSolution(t *testing.T) {
+	instance := loadInstance(t)
+	solution, err := ReadSolutionFile("testdata/toy-n11-k3.sol")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := NewStateFromSolution(instance, solution)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkState(t, s)
+	if s.Objective() != solution.Cost || s.Objective() != toyOptimal {
+		t.Fatalf("the cost %d expected, actual %f", toyOptimal, s.Objective())
+	}
+
+	var buf bytes.Buffer
+	if err := WriteSolution(&buf, s.Solution()); err != nil {
+		t.Fatal(err)
+	}
+	if expected := "Route #1: 1 4 3\nRoute #2: 2 6 7\nRoute #3: 8 5 10 9\nCost 453\n"; buf.String() != expected {
+		t.Fatalf("%q expected, actual %q", expected, buf.String())
+	}
+	parsed, err := ParseSolution(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(parsed, solution) {
+		t.Fatalf("%v expected, actual %v", solution, parsed)
+	}
+
+	overloaded := &Solution{Routes: [][]int{{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}}}
+	if _, err := NewStateFromSolution(instance, overloaded); err == nil {
+		t.Fatal("a capacity error expected")
+	}
+	incomplete := &Solution{Routes: [][]int{{1, 4, 3}}}
+	if _, err := NewStateFromSolution(instance, incomplete); err == nil {
+		t.Fatal("an unvisited customers error expected")
+	}
+}
+
+func TestState(t *testing.T) {
+	s := loadOptimal(t, loadInstance(t))
+	hash := s.Hash()
+
+	clone := s.Clone()
+	for _, customer := range []int{1, 4, 3} {
+		clone.Remove(customer)
+	}
+	if clone.NumRoutes() != 2 || len(clone.Unassigned()) != 3 {
+		t.Fatalf("2 routes and 3 unassigned customers expected, actual %d and %d", clone.NumRoutes(), len(clone.Unassigned()))
+	}
+	if s.Hash() != hash {
+		t.Fatal("the original state was mutated")
+	}
+	for i, customer := range []int{1, 4, 3} {
+		clone.Insert(customer, clone.NumRoutes()-min(i, 1), i)
+	}
+	if clone.Objective() != toyOptimal {
+		t.Fatalf("the cost %d expected, actual %f", toyOptimal, clone.Objective())
+	}
+	if clone.Hash() != hash {
+		t.Fatal("the hash is expected to not depend on the order of the routes")
+	}
+}
+
+func TestCapacity(t *testing.T) {
+	instance := loadInstance(t)
+	s := loadOptimal(t, instance)
+	for _, customer := range []int{4, 6, 10} {
+		s.Remove(customer)
+	}
+	for _, customer := range s.Unassigned() {
+		for r := range s.NumRoutes() {
+			fits := s.Load(r)+instance.Demands[customer] <= instance.Capacity
+			if s.CanInsert(customer, r) != fits {
+				t.Fatalf("the customer %d of the demand %d into the route %d of the load %d: %t expected",
+					customer, instance.Demands[customer], r, s.Load(r), fits)
+			}
+		}
+		if !s.CanInsert(customer, s.NumRoutes()) {
+			t.Fatalf("the customer %d is expected to fit into a new route", customer)
+		}
+	}
+
+	// the repairs never overload a route and need at least the total demand over the capacity routes
+	total := 0
+	for _, demand := range instance.Demands {
+		total += demand
+	}
+	minRoutes := (total + instance.Capacity - 1) / instance.Capacity
+	rnd := rand.New(rand.NewPCG(1, 2))
+	for name, repairOp := range map[string]alns.Operator{
+		"GreedyRepair": GreedyRepair(0.1),
+		"RegretRepair": RegretRepair(3, 0.1),
+	} {
+		t.Run(name, func(t *testing.T) {
+			s := initialState(t, instance, rnd)
+			for range 50 {
+				destroyed, err := RandomRemoval(0.5)(s, rnd)
+				if err != nil {
+					t.Fatal(err)
+				}
+				repaired, err := repairOp(destroyed, rnd)
+				if err != nil {
+					t.Fatal(err)
+				}
+				s = repaired.(*State)
+				checkState(t, s)
+				if s.NumRoutes() < minRoutes {
+					t.Fatalf("at least %d routes expected, actual %d", minRoutes, s.NumRoutes())
+				}
+			}
+		})
+	}
+}
+
+func TestDestroy(t *testing.T) {
+	instance := loadInstance(t)
+	rnd := rand.New(rand.NewPCG(1, 2))
+	initial := loadOptimal(t, instance)
+	routes := initial.Solution().Routes
+	fingerprint := initial.Fingerprint()
+
+	t.Run("RandomRemoval", func(t *testing.T) {
+		for degree, expected := range map[float64]int{0.3: 3, 0.01: 1, 1: 10} {
+			destroyed, err := RandomRemoval(degree)(initial, rnd)
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkState(t, destroyed.(*State))
+			if n := len(destroyed.(*State).Unassigned()); n != expected {
+				t.Fatalf("the degree %f: %d removed customers expected, actual %d", degree, expected, n)
+			}
+		}
+	})
+
+	t.Run("StringRemoval", func(t *testing.T) {
+		// every ruined route loses one string of consecutive customers
+		for range 50 {
+			destroyed, err := StringRemoval(3, 3)(initial, rnd)
+			if err != nil {
+				t.Fatal(err)
+			}
+			s := destroyed.(*State)
+			checkState(t, s)
+			if len(s.Unassigned()) == 0 {
+				t.Fatal("removed customers expected")
+			}
+			for _, route := range routes {
+				first, last := -1, -1
+				for pos, customer := range route {
+					if s.RouteOf(customer) == unassigned {
+						if first < 0 {
+							first = pos
+						}
+						last = pos
+					}
+				}
+				for _, customer := range route[max(first, 0) : last+1] {
+					if s.RouteOf(customer) != unassigned {
+						t.Fatalf("the removed customers of the route %v are not consecutive: %v", route, s.Unassigned())
+					}
+				}
+			}
+		}
+	})
+
+	if initial.Fingerprint() != fingerprint {
+		t.Fatal("the input state was mutated")
+	}
+}
+
+func TestRepair(t *testing.T) {
+	// a customer removed from the optimum is inserted back at its cheapest feasible position, which
+	// is not more expensive than the position it was removed from
+	instance := loadInstance(t)
+	rnd := rand.New(rand.NewPCG(1, 2))
+	for name, repairOp := range map[string]alns.Operator{
+		"GreedyRepair": GreedyRepair(0),
+		"RegretRepair": RegretRepair(3, 0),
+	} {
+		t.Run(name, func(t *testing.T) {
+			for customer := 1; customer < instance.Dimension; customer++ {
+				s := loadOptimal(t, instance)
+				s.Remove(customer)
+				cheapest := s.InsertCost(customer, s.NumRoutes(), 0)
+				for r := range s.NumRoutes() {
+					for pos := range len(s.Route(r)) + 1 {
+						if s.CanInsert(customer, r) {
+							cheapest = min(cheapest, s.InsertCost(customer, r, pos))
+						}
+					}
+				}
+				objective := s.Objective()
+				repaired, err := repairOp(s, rnd)
+				if err != nil {
+					t.Fatal(err)
+				}
+				checkState(t, repaired.(*State))
+				if got := repaired.Objective(); math.Abs(got-objective-cheapest) > 1e-6 || got != toyOptimal {
+					t.Fatalf("the customer %d: the cost %f expected, actual %f", customer, objective+cheapest, got)
+				}
+			}
+		})
+	}
+}
+
+func TestSolve(t *testing.T) {
+	instance := loadInstance(t)
+	rnd := rand.New(rand.NewPCG(12, 34))
+	initial := initialState(t, instance, rnd)
+
+	a := alns.ALNS{
+		Rnd: rnd,
+		DestroyOperators: []alns.Operator{
+			RandomRemoval(0.3),
+			StringRemoval(4, 3),
+		},
+		RepairOperators: []alns.Operator{GreedyRepair(0.01), RegretRepair(2, 0.01)},
+		CheckOperators:  true,
+	}
+	selector, err := alns.NewRouletteWheel([4]float64{3, 2, 1, 0.5}, 0.8, 2, 2, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	accept := alns.HillClimbing{}
+	stop := alns.MaxIterations{MaxIterations: 500}
+	result, err := a.Iterate(initial, &selector, &accept, &stop)
+	if err != nil {
+		t.Fatal(err)
+	}
+	best := result.BestState.(*State)
+	checkState(t, best)
+	if best.Objective() != toyOptimal {
+		t.Fatalf("the optimum %d expected, actual %f", toyOptimal, best.Objective())
+	}
+}
+
+func TestRegretRepairUrgency(t *testing.T) {
+	// the customer 3 fits only into the route of the customer 1 and the customer 4 has a larger
+	// regret, but it also fits into the route of the customer 2
+	data := "NAME : urgency\nTYPE : CVRP\nDIMENSION : 5\nEDGE_WEIGHT_TYPE : EUC_2D\nCAPACITY : 10\n" +
+		"NODE_COORD_SECTION\n1 0 0\n2 10 0\n3 0 10\n4 11 0\n5 9 1\n" +
+		"DEMAND_SECTION\n1 0\n2 1\n3 9\n4 9\n5 1\nDEPOT_SECTION\n1\n-1\nEOF\n"
+	instance, err := Parse(strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := NewState(instance)
+	s.Insert(1, 0, 0)
+	s.Insert(2, 1, 0)
+	repaired, err := RegretRepair(3, 0)(s, rand.New(rand.NewPCG(1, 2)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := repaired.(*State); r.NumRoutes() != 2 || r.Objective() != 54 {
+		t.Fatalf("2 routes of the cost 54 expected, actual %v", r.Solution())
+	}
+}
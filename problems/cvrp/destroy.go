@@ -0,0 +1,82 @@
+package cvrp
+
+import (
+	"math/rand/v2"
+	"slices"
+
+	"github.com/bibenga/alns"
+)
+
+// assigned returns the number of assigned customers.
+func assigned(state *State) int {
+	return state.instance.Dimension - 1 - len(state.Unassigned())
+}
+
+// RandomRemoval removes the fraction degree of the assigned customers chosen at random.
+func RandomRemoval(degree float64) alns.Operator {
+	return func(state alns.State, rnd *rand.Rand) (alns.State, error) {
+		destroyed := state.(*State).Clone()
+		n := assigned(destroyed)
+		toRemove := min(max(int(float64(n)*degree), 1), n)
+		for range toRemove {
+			destroyed.Remove(randomAssigned(destroyed, rnd))
+		}
+		return destroyed, nil
+	}
+}
+
+// StringRemoval is the ruin method of SISR (Christiaens & Vanden Berghe, 2020). It removes strings
+// of consecutive customers from the routes that are close to a random seed customer. The string
+// length is at most maxStringLength and avgRemoved customers are removed on average. Only the basic
+// string procedure is implemented, the split-string variant is not.
+func StringRemoval(maxStringLength int, avgRemoved float64) alns.Operator {
+	maxStringLength = max(maxStringLength, 1)
+	avgRemoved = max(avgRemoved, 1)
+	return func(state alns.State, rnd *rand.Rand) (alns.State, error) {
+		destroyed := state.(*State).Clone()
+		if destroyed.NumRoutes() == 0 {
+			return destroyed, nil
+		}
+
+		maxLength := min(float64(maxStringLength), float64(assigned(destroyed))/float64(destroyed.NumRoutes()))
+		maxStrings := 4*avgRemoved/(1+maxLength) - 1
+		numStrings := int(rnd.Float64()*maxStrings) + 1
+
+		seed := randomAssigned(destroyed, rnd)
+		ruined := make([]bool, destroyed.instance.Dimension) // the customers of the ruined routes
+		for _, customer := range append([]int{seed}, destroyed.instance.Neighbours(seed)...) {
+			if numStrings == 0 {
+				break
+			}
+			if destroyed.RouteOf(customer) == unassigned || ruined[customer] {
+				continue
+			}
+			for _, c := range destroyed.Route(destroyed.RouteOf(customer)) {
+				ruined[c] = true
+			}
+			removeString(destroyed, customer, maxLength, rnd)
+			numStrings--
+		}
+		return destroyed, nil
+	}
+}
+
+// removeString removes a string of random length that contains the customer from its route.
+func removeString(state *State, customer int, maxLength float64, rnd *rand.Rand) {
+	customers := state.Route(state.RouteOf(customer))
+	length := min(int(rnd.Float64()*min(float64(len(customers)), maxLength))+1, len(customers))
+	start := state.Position(customer) - rnd.IntN(length)
+	start = min(max(start, 0), len(customers)-length)
+	for _, c := range slices.Clone(customers[start : start+length]) {
+		state.Remove(c)
+	}
+}
+
+func randomAssigned(state *State, rnd *rand.Rand) int {
+	for {
+		customer := 1 + rnd.IntN(state.instance.Dimension-1)
+		if state.RouteOf(customer) != unassigned {
+			return customer
+		}
+	}
+}
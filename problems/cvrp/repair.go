@@ -0,0 +1,136 @@
+package cvrp
+
+import (
+	"cmp"
+	"math"
+	"math/rand/v2"
+	"slices"
+
+	"github.com/bibenga/alns"
+)
+
+// insertion is a position of a customer in a route, the route NumRoutes means a new route.
+type insertion struct {
+	route, pos int
+	cost       float64
+}
+
+// GreedyRepair is the recreate method of SISR (Christiaens & Vanden Berghe, 2020). The unassigned
+// customers are sorted randomly, by decreasing demand, by decreasing or by increasing distance from
+// the depot (with the weights 4, 4, 2 and 1) and each is inserted at its cheapest feasible position.
+// Every position is skipped with the probability blinkRate, a new route is opened if no position
+// is left.
+func GreedyRepair(blinkRate float64) alns.Operator {
+	return func(state alns.State, rnd *rand.Rand) (alns.State, error) {
+		repaired := state.(*State)
+		customers := slices.Clone(repaired.Unassigned())
+		sortCustomers(repaired.instance, customers, rnd)
+		for _, customer := range customers {
+			best := insertion{route: repaired.NumRoutes(), cost: math.Inf(1)}
+			for r := range repaired.NumRoutes() {
+				if !repaired.CanInsert(customer, r) {
+					continue
+				}
+				for pos := range len(repaired.Route(r)) + 1 {
+					if rnd.Float64() < blinkRate {
+						continue
+					}
+					if cost := repaired.InsertCost(customer, r, pos); cost < best.cost {
+						best = insertion{route: r, pos: pos, cost: cost}
+					}
+				}
+			}
+			repaired.Insert(customer, best.route, best.pos)
+		}
+		return repaired, nil
+	}
+}
+
+func sortCustomers(instance *Instance, customers []int, rnd *rand.Rand) {
+	rnd.Shuffle(len(customers), func(i, j int) {
+		customers[i], customers[j] = customers[j], customers[i]
+	})
+	d := instance.Dists[Depot]
+	switch w := rnd.IntN(11); {
+	case w < 4:
+	case w < 8:
+		slices.SortStableFunc(customers, func(a, b int) int {
+			return cmp.Compare(instance.Demands[b], instance.Demands[a])
+		})
+	case w < 10:
+		slices.SortStableFunc(customers, func(a, b int) int {
+			return cmp.Compare(d[b], d[a])
+		})
+	default:
+		slices.SortStableFunc(customers, func(a, b int) int {
+			return cmp.Compare(d[a], d[b])
+		})
+	}
+}
+
+// RegretRepair inserts the unassigned customers in the order of the largest regret-k value, i.e.
+// the sum of the differences between the cost of the best insertion and the cost of the best
+// insertions into the next k-1 routes. A new route counts as one more route, so every customer has
+// at least one insertion. A customer that fits into fewer than k routes is more urgent than the
+// customers with more routes whatever their regret. Every position is skipped with the probability
+// blinkRate.
+func RegretRepair(k int, blinkRate float64) alns.Operator {
+	k = max(k, 2)
+	return func(state alns.State, rnd *rand.Rand) (alns.State, error) {
+		repaired := state.(*State)
+		best := make([]insertion, 0, k)
+		for len(repaired.Unassigned()) > 0 {
+			var bestCustomer int
+			var bestInsertion insertion
+			bestMissing, bestRegret := -1, 0.0
+			for _, customer := range repaired.Unassigned() {
+				best = bestRouteInsertions(repaired, customer, k, blinkRate, rnd, best[:0])
+				// the fewer routes can serve the customer the more urgent it is
+				missing := k - len(best)
+				regret := 0.0
+				for _, other := range best[1:] {
+					regret += other.cost - best[0].cost
+				}
+				if c := cmp.Or(
+					cmp.Compare(missing, bestMissing),
+					cmp.Compare(regret, bestRegret),
+					cmp.Compare(bestInsertion.cost, best[0].cost),
+				); c > 0 {
+					bestCustomer, bestInsertion, bestMissing, bestRegret = customer, best[0], missing, regret
+				}
+			}
+			repaired.Insert(bestCustomer, bestInsertion.route, bestInsertion.pos)
+		}
+		return repaired, nil
+	}
+}
+
+// bestRouteInsertions returns the cheapest insertions of the customer into the k best routes
+// ordered by the cost, a new route is one of the candidates.
+func bestRouteInsertions(state *State, customer, k int, blinkRate float64, rnd *rand.Rand, best []insertion) []insertion {
+	candidates := append(best, insertion{
+		route: state.NumRoutes(),
+		cost:  state.InsertCost(customer, state.NumRoutes(), 0),
+	})
+	for r := range state.NumRoutes() {
+		if !state.CanInsert(customer, r) {
+			continue
+		}
+		routeBest := insertion{route: r, cost: math.Inf(1)}
+		for pos := range len(state.Route(r)) + 1 {
+			if rnd.Float64() < blinkRate {
+				continue
+			}
+			if cost := state.InsertCost(customer, r, pos); cost < routeBest.cost {
+				routeBest = insertion{route: r, pos: pos, cost: cost}
+			}
+		}
+		if !math.IsInf(routeBest.cost, 1) {
+			candidates = append(candidates, routeBest)
+		}
+	}
+	slices.SortFunc(candidates, func(a, b insertion) int {
+		return cmp.Compare(a.cost, b.cost)
+	})
+	return candidates[:min(k, len(candidates))]
+}
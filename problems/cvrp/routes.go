@@ -0,0 +1,220 @@
+package cvrp
+
+import (
+	"slices"
+)
+
+const unassigned = -1
+
+type route struct {
+	customers []int
+	load      int
+	cost      float64
+}
+
+// Routes is a set of routes that start and end at the depot, the bookkeeping shared by the states of
+// the routing problems (see State and vrptw.State), which add their constraints on top. The load and
+// the cost of every route are cached and updated from the neighbours when a customer is removed or
+// inserted, which takes O(route length) to find and shift the customers.
+type Routes struct {
+	dists      [][]float64
+	demands    []int
+	routes     []route
+	routeOf    []int // the route of each customer or unassigned
+	unassigned []int // the removed customers
+	cost       float64
+}
+
+// NewRoutes creates the routes of the nodes with the distances and the demands, the node 0 is
+// the depot and all customers are unassigned.
+func NewRoutes(dists [][]float64, demands []int) Routes {
+	r := Routes{
+		dists:      dists,
+		demands:    demands,
+		routeOf:    make([]int, len(dists)),
+		unassigned: make([]int, 0, len(dists)-1),
+	}
+	for i := range r.routeOf {
+		r.routeOf[i] = unassigned
+		if i != Depot {
+			r.unassigned = append(r.unassigned, i)
+		}
+	}
+	return r
+}
+
+func (r *Routes) Clone() Routes {
+	routes := make([]route, len(r.routes))
+	for i, rt := range r.routes {
+		routes[i] = route{
+			customers: slices.Clone(rt.customers),
+			load:      rt.load,
+			cost:      rt.cost,
+		}
+	}
+	return Routes{
+		dists:      r.dists,
+		demands:    r.demands,
+		routes:     routes,
+		routeOf:    slices.Clone(r.routeOf),
+		unassigned: slices.Clone(r.unassigned),
+		cost:       r.cost,
+	}
+}
+
+// TotalCost returns the total length of the routes.
+func (r *Routes) TotalCost() float64 {
+	return r.cost
+}
+
+// Unassigned returns the removed customers.
+func (r *Routes) Unassigned() []int {
+	return r.unassigned
+}
+
+// NumRoutes returns the number of non-empty routes.
+func (r *Routes) NumRoutes() int {
+	return len(r.routes)
+}
+
+// Route returns the customers of the route i in the visiting order, the slice must not be modified.
+func (r *Routes) Route(i int) []int {
+	return r.routes[i].customers
+}
+
+// Load returns the total demand of the route i.
+func (r *Routes) Load(i int) int {
+	return r.routes[i].load
+}
+
+// Cost returns the length of the route i including the edges from and to the depot.
+func (r *Routes) Cost(i int) float64 {
+	return r.routes[i].cost
+}
+
+// RouteOf returns the route of the customer or -1 if the customer is unassigned.
+func (r *Routes) RouteOf(customer int) int {
+	return r.routeOf[customer]
+}
+
+// Position returns the position of the assigned customer in its route.
+func (r *Routes) Position(customer int) int {
+	return slices.Index(r.routes[r.routeOf[customer]].customers, customer)
+}
+
+// Adjacent returns the nodes before and after the position pos of the route i.
+func (r *Routes) Adjacent(i, pos int) (prev, next int) {
+	customers := r.routes[i].customers
+	prev, next = Depot, Depot
+	if pos > 0 {
+		prev = customers[pos-1]
+	}
+	if pos < len(customers) {
+		next = customers[pos]
+	}
+	return prev, next
+}
+
+// InsertCost returns the change of the total cost if the customer is inserted at the position pos
+// of the route i, i equal to NumRoutes means a new route.
+func (r *Routes) InsertCost(customer, i, pos int) float64 {
+	d := r.dists
+	if i == len(r.routes) {
+		return d[Depot][customer] + d[customer][Depot]
+	}
+	prev, next := r.Adjacent(i, pos)
+	return d[prev][customer] + d[customer][next] - d[prev][next]
+}
+
+// Insert assigns the removed customer at the position pos of the route i, i equal to NumRoutes
+// opens a new route. No constraint is checked.
+func (r *Routes) Insert(customer, i, pos int) {
+	delta := r.InsertCost(customer, i, pos)
+	if i == len(r.routes) {
+		r.routes = append(r.routes, route{})
+	}
+	rt := &r.routes[i]
+	rt.customers = slices.Insert(rt.customers, pos, customer)
+	rt.load += r.demands[customer]
+	rt.cost += delta
+	r.cost += delta
+	r.routeOf[customer] = i
+	if j := slices.Index(r.unassigned, customer); j >= 0 {
+		r.unassigned[j] = r.unassigned[len(r.unassigned)-1]
+		r.unassigned = r.unassigned[:len(r.unassigned)-1]
+	}
+}
+
+// Remove unassigns the customer and returns its route i. The route is dropped if it becomes empty,
+// then the last route takes the index i and dropped is true.
+func (r *Routes) Remove(customer int) (i int, dropped bool) {
+	i = r.routeOf[customer]
+	rt := &r.routes[i]
+	pos := slices.Index(rt.customers, customer)
+	prev, _ := r.Adjacent(i, pos)
+	_, next := r.Adjacent(i, pos+1)
+	d := r.dists
+	delta := d[prev][next] - d[prev][customer] - d[customer][next]
+
+	rt.customers = slices.Delete(rt.customers, pos, pos+1)
+	rt.load -= r.demands[customer]
+	rt.cost += delta
+	r.cost += delta
+	r.routeOf[customer] = unassigned
+	r.unassigned = append(r.unassigned, customer)
+
+	if len(rt.customers) > 0 {
+		return i, false
+	}
+	r.cost -= rt.cost
+	last := len(r.routes) - 1
+	if i != last {
+		r.routes[i] = r.routes[last]
+		for _, c := range r.routes[i].customers {
+			r.routeOf[c] = i
+		}
+	}
+	r.routes = r.routes[:last]
+	return i, true
+}
+
+// Solution returns the routes in the CVRPLIB format.
+func (r *Routes) Solution() *Solution {
+	solution := Solution{
+		Routes: make([][]int, len(r.routes)),
+		Cost:   r.cost,
+	}
+	for i, rt := range r.routes {
+		solution.Routes[i] = slices.Clone(rt.customers)
+	}
+	return &solution
+}
+
+// Hash returns the hash of the successor of every customer, so it does not depend on the order
+// of the routes.
+func (r *Routes) Hash() uint64 {
+	next := make([]int, len(r.routeOf))
+	for i := range next {
+		next[i] = unassigned
+	}
+	for _, rt := range r.routes {
+		for i, customer := range rt.customers {
+			if i+1 < len(rt.customers) {
+				next[customer] = rt.customers[i+1]
+			} else {
+				next[customer] = Depot
+			}
+		}
+	}
+	// FNV-1a
+	h := uint64(14695981039346656037)
+	for _, n := range next {
+		h ^= uint64(n)
+		h *= 1099511628211
+	}
+	return h
+}
+
+func (r *Routes) Fingerprint() uint64 {
+	return r.Hash()
+}
@@ -0,0 +1,73 @@
+package cvrp
+
+import (
+	"fmt"
+
+	"github.com/bibenga/alns"
+)
+
+// State is a set of routes (see Routes) whose loads must not exceed the vehicle capacity. The removed
+// customers are inserted back by the repair operators.
+type State struct {
+	Routes
+	instance *Instance
+}
+
+var (
+	_ alns.State         = &State{}
+	_ alns.Hasher        = &State{}
+	_ alns.Fingerprinter = &State{}
+)
+
+// NewState creates a state without routes, all customers are unassigned.
+func NewState(instance *Instance) *State {
+	return &State{
+		Routes:   NewRoutes(instance.Dists, instance.Demands),
+		instance: instance,
+	}
+}
+
+// NewStateFromSolution creates a state from a complete solution.
+func NewStateFromSolution(instance *Instance, solution *Solution) (*State, error) {
+	s := NewState(instance)
+	for _, customers := range solution.Routes {
+		if len(customers) == 0 {
+			continue
+		}
+		r := s.NumRoutes()
+		for i, customer := range customers {
+			if customer <= Depot || customer >= instance.Dimension || s.RouteOf(customer) != unassigned {
+				return nil, fmt.Errorf("invalid customer %d", customer)
+			}
+			s.Insert(customer, r, i)
+		}
+		if s.Load(r) > instance.Capacity {
+			return nil, fmt.Errorf("the load %d of the route %d exceeds the capacity", s.Load(r), r+1)
+		}
+	}
+	if len(s.Unassigned()) != 0 {
+		return nil, fmt.Errorf("%d customers are not visited", len(s.Unassigned()))
+	}
+	return s, nil
+}
+
+func (s *State) Clone() *State {
+	return &State{
+		Routes:   s.Routes.Clone(),
+		instance: s.instance,
+	}
+}
+
+// Objective returns the total cost of the routes.
+func (s *State) Objective() float64 {
+	return s.TotalCost()
+}
+
+func (s *State) Instance() *Instance {
+	return s.instance
+}
+
+// CanInsert reports whether the customer fits into the route r, a new route always fits.
+func (s *State) CanInsert(customer, r int) bool {
+	return r == s.NumRoutes() || s.Load(r)+s.instance.Demands[customer] <= s.instance.Capacity
+}
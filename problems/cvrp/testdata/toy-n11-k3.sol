@@ -0,0 +1,4 @@
+Route #1: 1 4 3
+Route #2: 2 6 7
+Route #3: 8 5 10 9
+Cost 453
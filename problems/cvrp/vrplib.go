@@ -0,0 +1,243 @@
+// Package cvrp solves the capacitated vehicle routing problem with ALNS.
+//
+// The nodes are numbered from 0 and the depot must be the first node, so that the customers keep
+// the numbers used in the CVRPLIB solution files (.sol).
+package cvrp
+
+import (
+	"bufio"
+	"cmp"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/bibenga/alns/tsplib"
+)
+
+// Instance is a CVRP instance.
+type Instance struct {
+	Name      string
+	Comment   string
+	Dimension int          // the number of nodes including the depot
+	Capacity  int          // the vehicle capacity
+	Coords    [][2]float64 // the node coordinates
+	Demands   []int        // the customer demands, zero for the depot
+	Dists     [][]float64  // the rounded euclidean distances
+
+	neighboursOnce sync.Once
+	neighbours     [][]int
+}
+
+// Depot is the index of the depot node.
+const Depot = 0
+
+// Neighbours returns the customers ordered by the distance from the node, the list is computed
+// on the first call and must not be modified.
+func (instance *Instance) Neighbours(node int) []int {
+	instance.neighboursOnce.Do(func() {
+		instance.neighbours = make([][]int, instance.Dimension)
+		for i := range instance.neighbours {
+			customers := make([]int, 0, instance.Dimension-1)
+			for j := range instance.Dimension {
+				if j != Depot && j != i {
+					customers = append(customers, j)
+				}
+			}
+			slices.SortStableFunc(customers, func(a, b int) int {
+				return cmp.Compare(instance.Dists[i][a], instance.Dists[i][b])
+			})
+			instance.neighbours[i] = customers
+		}
+	})
+	return instance.neighbours[node]
+}
+
+// ReadFile reads an instance file in the VRPLIB format (EUC_2D only).
+func ReadFile(name string) (*Instance, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Parse(f)
+}
+
+// Parse parses an instance in the VRPLIB format (EUC_2D only).
+func Parse(r io.Reader) (*Instance, error) {
+	file, err := tsplib.ParseSections(r)
+	if err != nil {
+		return nil, err
+	}
+	headers, sections := file.Specification, file.Data
+
+	if headers["TYPE"] != "CVRP" {
+		return nil, fmt.Errorf("unsupported type %q", headers["TYPE"])
+	}
+	if headers["EDGE_WEIGHT_TYPE"] != "EUC_2D" {
+		return nil, fmt.Errorf("unsupported edge weight type %q", headers["EDGE_WEIGHT_TYPE"])
+	}
+	dimension, err := strconv.Atoi(headers["DIMENSION"])
+	if err != nil || dimension < 2 {
+		return nil, fmt.Errorf("invalid DIMENSION %q", headers["DIMENSION"])
+	}
+	capacity, err := strconv.Atoi(headers["CAPACITY"])
+	if err != nil || capacity <= 0 {
+		return nil, fmt.Errorf("invalid CAPACITY %q", headers["CAPACITY"])
+	}
+
+	instance := Instance{
+		Name:      headers["NAME"],
+		Comment:   headers["COMMENT"],
+		Dimension: dimension,
+		Capacity:  capacity,
+		Coords:    make([][2]float64, dimension),
+		Demands:   make([]int, dimension),
+	}
+
+	coords, err := parseRows(sections, "NODE_COORD_SECTION", dimension, 2)
+	if err != nil {
+		return nil, err
+	}
+	for i, row := range coords {
+		instance.Coords[i] = [2]float64{row[0], row[1]}
+	}
+	demands, err := parseRows(sections, "DEMAND_SECTION", dimension, 1)
+	if err != nil {
+		return nil, err
+	}
+	for i, row := range demands {
+		instance.Demands[i] = int(row[0])
+		if instance.Demands[i] > capacity {
+			return nil, fmt.Errorf("the demand %d of node %d exceeds the capacity", instance.Demands[i], i+1)
+		}
+	}
+	if depots := sections["DEPOT_SECTION"]; len(depots) == 0 || depots[0] != "1" {
+		return nil, fmt.Errorf("the depot must be the node 1")
+	}
+	instance.Demands[Depot] = 0
+
+	instance.Dists = make([][]float64, dimension)
+	for i, a := range instance.Coords {
+		instance.Dists[i] = make([]float64, dimension)
+		for j, b := range instance.Coords {
+			instance.Dists[i][j] = math.Floor(math.Hypot(a[0]-b[0], a[1]-b[1]) + 0.5)
+		}
+	}
+	return &instance, nil
+}
+
+// parseRows parses the rows "id value..." of a section, the rows are ordered by the node id.
+func parseRows(sections map[string][]string, name string, dimension, columns int) ([][]float64, error) {
+	tokens, ok := sections[name]
+	if !ok {
+		return nil, fmt.Errorf("missing %s", name)
+	}
+	if len(tokens) != dimension*(columns+1) {
+		return nil, fmt.Errorf("%s: %d rows expected", name, dimension)
+	}
+	rows := make([][]float64, dimension)
+	for i := range dimension {
+		id, err := strconv.Atoi(tokens[i*(columns+1)])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		if id < 1 || id > dimension || rows[id-1] != nil {
+			return nil, fmt.Errorf("%s: invalid node %d", name, id)
+		}
+		row := make([]float64, columns)
+		for j := range columns {
+			if row[j], err = strconv.ParseFloat(tokens[i*(columns+1)+1+j], 64); err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+		}
+		rows[id-1] = row
+	}
+	return rows, nil
+}
+
+// Solution is a set of routes in the CVRPLIB solution format, the customers are numbered from 1
+// and the depot is not included.
+type Solution struct {
+	Routes [][]int
+	Cost   float64
+}
+
+// ReadSolutionFile reads a .sol file.
+func ReadSolutionFile(name string) (*Solution, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ParseSolution(f)
+}
+
+// ParseSolution parses a solution in the CVRPLIB format.
+func ParseSolution(r io.Reader) (*Solution, error) {
+	solution := Solution{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "":
+		case strings.HasPrefix(line, "Route"):
+			_, customers, found := strings.Cut(line, ":")
+			if !found {
+				return nil, fmt.Errorf("invalid route %q", line)
+			}
+			route := []int{}
+			for _, field := range strings.Fields(customers) {
+				customer, err := strconv.Atoi(field)
+				if err != nil {
+					return nil, err
+				}
+				route = append(route, customer)
+			}
+			solution.Routes = append(solution.Routes, route)
+		case strings.HasPrefix(line, "Cost"):
+			cost, err := strconv.ParseFloat(strings.TrimSpace(strings.TrimPrefix(line, "Cost")), 64)
+			if err != nil {
+				return nil, err
+			}
+			solution.Cost = cost
+		default:
+			return nil, fmt.Errorf("unexpected line %q", line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return &solution, nil
+}
+
+// WriteSolutionFile writes the solution to a .sol file.
+func WriteSolutionFile(name string, solution *Solution) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if err := WriteSolution(f, solution); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// WriteSolution writes the solution in the CVRPLIB format.
+func WriteSolution(w io.Writer, solution *Solution) error {
+	bw := bufio.NewWriter(w)
+	for i, route := range solution.Routes {
+		fmt.Fprintf(bw, "Route #%d:", i+1)
+		for _, customer := range route {
+			fmt.Fprintf(bw, " %d", customer)
+		}
+		fmt.Fprintln(bw)
+	}
+	fmt.Fprintf(bw, "Cost %s\n", strconv.FormatFloat(solution.Cost, 'f', -1, 64))
+	return bw.Flush()
+}
@@ -0,0 +1,48 @@
+package pfsp
+
+import (
+	"math/rand/v2"
+
+	"github.com/bibenga/alns"
+)
+
+// jobsToRemove returns the number of jobs removed by the destroy operators for the degree of destruction.
+func jobsToRemove(state *State, degree float64) int {
+	n := len(state.sequence)
+	return min(max(int(float64(n)*degree), 1), n)
+}
+
+// RandomRemoval removes random jobs.
+func RandomRemoval(degree float64) alns.Operator {
+	return func(state alns.State, rnd *rand.Rand) (alns.State, error) {
+		destroyed := state.(*State).Clone()
+		for range jobsToRemove(destroyed, degree) {
+			destroyed.Remove(rnd.IntN(len(destroyed.sequence)))
+		}
+		return destroyed, nil
+	}
+}
+
+// AdjacentRemoval removes a block of consecutive jobs starting at a random position.
+func AdjacentRemoval(degree float64) alns.Operator {
+	return func(state alns.State, rnd *rand.Rand) (alns.State, error) {
+		destroyed := state.(*State).Clone()
+		count := jobsToRemove(destroyed, degree)
+		start := rnd.IntN(len(destroyed.sequence) - count + 1)
+		for range count {
+			destroyed.Remove(start)
+		}
+		return destroyed, nil
+	}
+}
+
+// TailRemoval removes the last jobs of the sequence.
+func TailRemoval(degree float64) alns.Operator {
+	return func(state alns.State, rnd *rand.Rand) (alns.State, error) {
+		destroyed := state.(*State).Clone()
+		for range jobsToRemove(destroyed, degree) {
+			destroyed.Remove(len(destroyed.sequence) - 1)
+		}
+		return destroyed, nil
+	}
+}
@@ -0,0 +1,325 @@
+package pfsp
+
+import (
+	"cmp"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/bibenga/alns"
+)
+
+// the NEH makespans of ta001 and ta002
+var nehMakespans = []int{1286, 1365}
+
+func loadInstances(t testing.TB) []*Instance {
+	instances, err := ReadFile("testdata/tai20_5.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return instances
+}
+
+// makespan computes the makespan of the sequence from scratch.
+func makespan(instance *Instance, sequence []int) int {
+	start := make([][]int, instance.Machines)
+	for i := range start {
+		start[i] = make([]int, len(sequence)+1)
+	}
+	last := 0
+	for k, job := range sequence {
+		for i := range instance.Machines {
+			ready := start[i][k]
+			if i > 0 {
+				ready = max(ready, start[i-1][k+1])
+			}
+			start[i][k+1] = ready + instance.Times[i][job]
+			last = start[i][k+1]
+		}
+	}
+	return last
+}
+
+// checkSchedule checks that the sequence and the removed jobs are a permutation of the jobs and
+// that the cached makespan is the makespan of the sequence.
+func checkSchedule(t *testing.T, s *State) {
+	t.Helper()
+	if expected := makespan(s.instance, s.Sequence()); s.Makespan() != expected {
+		t.Fatalf("the cached makespan %d differs from %d", s.Makespan(), expected)
+	}
+	jobs := append(slices.Clone(s.Sequence()), s.Removed()...)
+	slices.Sort(jobs)
+	for j, job := range jobs {
+		if j != job {
+			t.Fatalf("the sequence %v and the removed jobs %v are not a permutation", s.Sequence(), s.Removed())
+		}
+	}
+	if len(jobs) != s.instance.Jobs {
+		t.Fatalf("%d jobs expected, actual %d", s.instance.Jobs, len(jobs))
+	}
+}
+
+// johnson returns the optimal sequence of a two machine instance by Johnson's rule (1954).
+func johnson(instance *Instance) []int {
+	p := instance.Times
+	var first, second []int
+	for job := range instance.Jobs {
+		if p[0][job] < p[1][job] {
+			first = append(first, job)
+		} else {
+			second = append(second, job)
+		}
+	}
+	slices.SortStableFunc(first, func(a, b int) int { return cmp.Compare(p[0][a], p[0][b]) })
+	slices.SortStableFunc(second, func(a, b int) int { return cmp.Compare(p[1][b], p[1][a]) })
+	return append(first, second...)
+}
+
+func TestParse(t *testing.T) {
+	instances := loadInstances(t)
+	if len(instances) != 2 {
+		t.Fatalf("2 instances expected, actual %d", len(instances))
+	}
+	ta001 := instances[0]
+	if ta001.Jobs != 20 || ta001.Machines != 5 || ta001.Seed != 873654221 || ta001.UpperBound != 1278 || ta001.LowerBound != 1232 {
+		t.Fatalf("unexpected header %+v", ta001)
+	}
+	if ta001.Times[0][0] != 54 || ta001.Times[0][19] != 94 || ta001.Times[4][19] != 28 {
+		t.Fatalf("unexpected processing times %v", ta001.Times)
+	}
+
+	invalid := map[string]string{
+		"incomplete": "number of jobs :\n 2 2 1 10 10\nprocessing times :\n 1 2\n 3\n",
+		"too many":   "number of jobs :\n 2 2 1 10 10\nprocessing times :\n 1 2\n 3 4 5\n",
+		"not number": "number of jobs :\n 2 2 1 10 10\nprocessing times :\n 1 2\n 3 x\n",
+		"empty":      "",
+	}
+	for name, data := range invalid {
+		t.Run(name, func(t *testing.T) {
+			if _, err := Parse(strings.NewReader(data)); err == nil {
+				t.Fatal("an error expected")
+			}
+		})
+	}
+}
+
+func TestMakespanBounds(t *testing.T) {
+	rnd := rand.New(rand.NewPCG(1, 2))
+	for _, instance := range loadInstances(t) {
+		// the makespan is at least the load of every machine and the total time of every job
+		bound := 0
+		for i := range instance.Machines {
+			load := 0
+			for job := range instance.Jobs {
+				load += instance.Times[i][job]
+			}
+			bound = max(bound, load)
+		}
+		for job := range instance.Jobs {
+			total := 0
+			for i := range instance.Machines {
+				total += instance.Times[i][job]
+			}
+			bound = max(bound, total)
+		}
+		if bound > instance.LowerBound {
+			t.Fatalf("the lower bound %d of the instance is below the trivial bound %d", instance.LowerBound, bound)
+		}
+		for range 20 {
+			s, err := NewStateFromSequence(instance, rnd.Perm(instance.Jobs))
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkSchedule(t, s)
+			if s.Makespan() < instance.UpperBound {
+				t.Fatalf("the makespan %d is below the optimum %d", s.Makespan(), instance.UpperBound)
+			}
+		}
+	}
+}
+
+func TestInsertMakespans(t *testing.T) {
+	instance := loadInstances(t)[0]
+	rnd := rand.New(rand.NewPCG(1, 2))
+	perm := rnd.Perm(instance.Jobs)
+	s, err := NewStateFromSequence(instance, perm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkSchedule(t, s)
+	for range 5 {
+		// removing a job never delays the other jobs
+		before := s.Makespan()
+		s.Remove(rnd.IntN(len(s.Sequence())))
+		checkSchedule(t, s)
+		if s.Makespan() > before {
+			t.Fatalf("the makespan %d increased to %d after a removal", before, s.Makespan())
+		}
+	}
+	for _, job := range slices.Clone(s.Removed()) {
+		makespans := s.InsertMakespans(job)
+		for pos, actual := range makespans {
+			expected := makespan(instance, slices.Insert(slices.Clone(s.Sequence()), pos, job))
+			if actual != expected {
+				t.Fatalf("the job %d at %d: makespan %d expected, actual %d", job, pos, expected, actual)
+			}
+		}
+		s.Insert(job, rnd.IntN(len(makespans)))
+		checkSchedule(t, s)
+	}
+
+	if _, err := NewStateFromSequence(instance, append(perm[:19:19], perm[0])); err == nil {
+		t.Fatal("invalid sequence error expected")
+	}
+}
+
+func TestNEH(t *testing.T) {
+	for i, instance := range loadInstances(t) {
+		s, err := NEHRepair(NewState(instance), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkSchedule(t, s.(*State))
+		if s.(*State).Makespan() != nehMakespans[i] {
+			t.Fatalf("the NEH makespan %d expected, actual %d", nehMakespans[i], s.(*State).Makespan())
+		}
+	}
+}
+
+func TestDestroy(t *testing.T) {
+	instance := loadInstances(t)[0]
+	rnd := rand.New(rand.NewPCG(1, 2))
+	initial, _ := NEHRepair(NewState(instance), rnd)
+	sequence := initial.(*State).Sequence()
+
+	for name, tc := range map[string]struct {
+		destroyOp alns.Operator
+		check     func(removed []int) bool
+	}{
+		"RandomRemoval": {RandomRemoval(0.2), func(removed []int) bool { return true }},
+		"AdjacentRemoval": {AdjacentRemoval(0.2), func(removed []int) bool {
+			start := slices.Index(sequence, removed[0])
+			return start+len(removed) <= len(sequence) && slices.Equal(sequence[start:start+len(removed)], removed)
+		}},
+		"TailRemoval": {TailRemoval(0.2), func(removed []int) bool {
+			tail := slices.Clone(sequence[len(sequence)-len(removed):])
+			slices.Reverse(tail)
+			return slices.Equal(tail, removed)
+		}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			fingerprint := initial.(*State).Fingerprint()
+			destroyed, err := tc.destroyOp(initial, rnd)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if initial.(*State).Fingerprint() != fingerprint {
+				t.Fatal("the input state was mutated")
+			}
+			s := destroyed.(*State)
+			checkSchedule(t, s)
+			if len(s.Removed()) != 4 || !tc.check(s.Removed()) {
+				t.Fatalf("unexpected removed jobs %v of the sequence %v", s.Removed(), sequence)
+			}
+		})
+	}
+}
+
+func TestRepair(t *testing.T) {
+	instance := loadInstances(t)[0]
+	rnd := rand.New(rand.NewPCG(1, 2))
+	for name, repairOp := range map[string]alns.Operator{
+		"NEHRepair":    NEHRepair,
+		"GreedyRepair": GreedyRepair,
+	} {
+		t.Run(name, func(t *testing.T) {
+			// a single job is inserted at the position of the smallest makespan
+			s, _ := NewStateFromSequence(instance, rnd.Perm(instance.Jobs))
+			s.Remove(rnd.IntN(instance.Jobs))
+			best := slices.Min(s.InsertMakespans(s.Removed()[0]))
+			repaired, err := repairOp(s, rnd)
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkSchedule(t, repaired.(*State))
+			if repaired.(*State).Makespan() != best {
+				t.Fatalf("the makespan %d expected, actual %d", best, repaired.(*State).Makespan())
+			}
+
+			repaired, err = repairOp(NewState(instance), rnd)
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkSchedule(t, repaired.(*State))
+			if len(repaired.(*State).Removed()) != 0 {
+				t.Fatal("all jobs are expected to be inserted")
+			}
+		})
+	}
+}
+
+func TestSolve(t *testing.T) {
+	instance := loadInstances(t)[0]
+	rnd := rand.New(rand.NewPCG(12, 34))
+	initial, _ := NEHRepair(NewState(instance), rnd)
+
+	a := alns.ALNS{
+		Rnd: rnd,
+		DestroyOperators: []alns.Operator{
+			RandomRemoval(0.2),
+			AdjacentRemoval(0.2),
+			TailRemoval(0.2),
+		},
+		RepairOperators: []alns.Operator{NEHRepair, GreedyRepair},
+		CheckOperators:  true,
+	}
+	selector, err := alns.NewRouletteWheel([4]float64{3, 2, 1, 0.5}, 0.8, 3, 2, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	accept := alns.HillClimbing{}
+	stop := alns.MaxIterations{MaxIterations: 2000}
+	result, err := a.Iterate(initial, &selector, &accept, &stop)
+	if err != nil {
+		t.Fatal(err)
+	}
+	best := result.BestState.(*State)
+	checkSchedule(t, best)
+	if best.Makespan() >= nehMakespans[0] || best.Makespan() < instance.UpperBound {
+		t.Fatalf("a makespan better than NEH %d expected, actual %d (the optimum is %d)",
+			nehMakespans[0], best.Makespan(), instance.UpperBound)
+	}
+}
+
+func TestSolveTwoMachines(t *testing.T) {
+	// Johnson's rule gives the optimum of two machines
+	rnd := rand.New(rand.NewPCG(1, 2))
+	instance := &Instance{Jobs: 12, Machines: 2, Times: make([][]int, 2)}
+	for i := range instance.Times {
+		instance.Times[i] = make([]int, instance.Jobs)
+		for job := range instance.Jobs {
+			instance.Times[i][job] = 1 + rnd.IntN(99)
+		}
+	}
+	optimal := makespan(instance, johnson(instance))
+
+	a := alns.ALNS{
+		Rnd:              rnd,
+		DestroyOperators: []alns.Operator{RandomRemoval(0.3), AdjacentRemoval(0.3)},
+		RepairOperators:  []alns.Operator{GreedyRepair},
+	}
+	selector, err := alns.NewRouletteWheel([4]float64{3, 2, 1, 0.5}, 0.8, 2, 1, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	initial, _ := GreedyRepair(NewState(instance), rnd)
+	stop := alns.MaxIterations{MaxIterations: 500}
+	result, err := a.Iterate(initial, &selector, &alns.HillClimbing{}, &stop)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if best := result.BestState.(*State); best.Makespan() != optimal {
+		t.Fatalf("the optimal makespan %d expected, actual %d", optimal, best.Makespan())
+	}
+}
@@ -0,0 +1,56 @@
+package pfsp
+
+import (
+	"cmp"
+	"math/rand/v2"
+	"slices"
+
+	"github.com/bibenga/alns"
+)
+
+// NEHRepair inserts the removed jobs in the order of the decreasing total processing time, each
+// at the position that minimizes the makespan (the first one on ties), see Nawaz, Enscore & Ham
+// (1983). Applied to an empty sequence it is the NEH heuristic.
+func NEHRepair(state alns.State, rnd *rand.Rand) (alns.State, error) {
+	repaired := state.(*State)
+	p := repaired.instance.Times
+	total := func(job int) int {
+		sum := 0
+		for i := range p {
+			sum += p[i][job]
+		}
+		return sum
+	}
+	jobs := slices.Clone(repaired.Removed())
+	slices.SortFunc(jobs, func(a, b int) int {
+		return cmp.Or(cmp.Compare(total(b), total(a)), cmp.Compare(a, b))
+	})
+	for _, job := range jobs {
+		makespans := repaired.InsertMakespans(job)
+		repaired.Insert(job, slices.Index(makespans, slices.Min(makespans)))
+	}
+	return repaired, nil
+}
+
+// GreedyRepair inserts the removed jobs in random order, each at the position that minimizes the
+// makespan (a random one on ties), it is the construction phase of the iterated greedy algorithm
+// of Ruiz & Stützle (2007).
+func GreedyRepair(state alns.State, rnd *rand.Rand) (alns.State, error) {
+	repaired := state.(*State)
+	jobs := slices.Clone(repaired.Removed())
+	rnd.Shuffle(len(jobs), func(i, j int) {
+		jobs[i], jobs[j] = jobs[j], jobs[i]
+	})
+	for _, job := range jobs {
+		makespans := repaired.InsertMakespans(job)
+		best := slices.Min(makespans)
+		positions := make([]int, 0, len(makespans))
+		for pos, makespan := range makespans {
+			if makespan == best {
+				positions = append(positions, pos)
+			}
+		}
+		repaired.Insert(job, positions[rnd.IntN(len(positions))])
+	}
+	return repaired, nil
+}
@@ -0,0 +1,192 @@
+package pfsp
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/bibenga/alns"
+)
+
+// State is a sequence of the scheduled jobs, the removed jobs are inserted back by the repair
+// operators. The makespan of the partial sequence is cached.
+type State struct {
+	instance *Instance
+	sequence []int
+	removed  []int
+	makespan int
+}
+
+var (
+	_ alns.State         = &State{}
+	_ alns.Hasher        = &State{}
+	_ alns.Fingerprinter = &State{}
+)
+
+// NewState creates an empty sequence, all jobs are removed.
+func NewState(instance *Instance) *State {
+	s := State{
+		instance: instance,
+		sequence: make([]int, 0, instance.Jobs),
+		removed:  make([]int, instance.Jobs),
+	}
+	for j := range s.removed {
+		s.removed[j] = j
+	}
+	return &s
+}
+
+// NewStateFromSequence creates a state from a permutation of all jobs.
+func NewStateFromSequence(instance *Instance, sequence []int) (*State, error) {
+	if len(sequence) != instance.Jobs {
+		return nil, fmt.Errorf("%d jobs expected, actual %d", instance.Jobs, len(sequence))
+	}
+	s := NewState(instance)
+	for _, job := range sequence {
+		if job < 0 || job >= instance.Jobs || slices.Contains(s.sequence, job) {
+			return nil, fmt.Errorf("invalid job %d", job)
+		}
+		s.sequence = append(s.sequence, job)
+	}
+	s.removed = s.removed[:0]
+	s.evaluate()
+	return s, nil
+}
+
+func (s *State) Clone() *State {
+	return &State{
+		instance: s.instance,
+		sequence: slices.Clone(s.sequence),
+		removed:  slices.Clone(s.removed),
+		makespan: s.makespan,
+	}
+}
+
+// Objective returns the makespan of the scheduled jobs.
+func (s *State) Objective() float64 {
+	return float64(s.makespan)
+}
+
+func (s *State) Instance() *Instance {
+	return s.instance
+}
+
+// Makespan returns the completion time of the last scheduled job on the last machine.
+func (s *State) Makespan() int {
+	return s.makespan
+}
+
+// Sequence returns the scheduled jobs in the processing order, the slice must not be modified.
+func (s *State) Sequence() []int {
+	return s.sequence
+}
+
+// Removed returns the unscheduled jobs.
+func (s *State) Removed() []int {
+	return s.removed
+}
+
+// evaluate recomputes the makespan in O(jobs * machines).
+func (s *State) evaluate() {
+	p := s.instance.Times
+	completion := make([]int, s.instance.Machines)
+	for _, job := range s.sequence {
+		completion[0] += p[0][job]
+		for i := 1; i < len(completion); i++ {
+			completion[i] = max(completion[i], completion[i-1]) + p[i][job]
+		}
+	}
+	s.makespan = completion[len(completion)-1]
+}
+
+// Remove unschedules the job at the position pos.
+func (s *State) Remove(pos int) {
+	s.removed = append(s.removed, s.sequence[pos])
+	s.sequence = slices.Delete(s.sequence, pos, pos+1)
+	s.evaluate()
+}
+
+// Insert schedules the removed job at the position pos.
+func (s *State) Insert(job, pos int) {
+	s.sequence = slices.Insert(s.sequence, pos, job)
+	if i := slices.Index(s.removed, job); i >= 0 {
+		s.removed = slices.Delete(s.removed, i, i+1)
+	}
+	s.evaluate()
+}
+
+// InsertMakespans returns the makespan after the insertion of the job at every position
+// 0..len(Sequence()). It uses Taillard's acceleration, so all positions are evaluated in
+// O(jobs * machines) instead of O(jobs^2 * machines).
+func (s *State) InsertMakespans(job int) []int {
+	p := s.instance.Times
+	n, m := len(s.sequence), s.instance.Machines
+
+	// e[k][i] is the earliest completion of the first k jobs on the machine i,
+	// q[k][i] is the length of the critical path from the start of the job k on the machine i to the end
+	e := make([][]int, n+1)
+	q := make([][]int, n+1)
+	for k := range n + 1 {
+		e[k] = make([]int, m)
+		q[k] = make([]int, m)
+	}
+	for k := 1; k <= n; k++ {
+		job := s.sequence[k-1]
+		for i := range m {
+			prev := 0
+			if i > 0 {
+				prev = e[k][i-1]
+			}
+			e[k][i] = max(e[k-1][i], prev) + p[i][job]
+		}
+	}
+	for k := n - 1; k >= 0; k-- {
+		job := s.sequence[k]
+		for i := m - 1; i >= 0; i-- {
+			next := 0
+			if i < m-1 {
+				next = q[k][i+1]
+			}
+			after := 0
+			if k < n-1 {
+				after = q[k+1][i]
+			}
+			q[k][i] = max(next, after) + p[i][job]
+		}
+	}
+
+	makespans := make([]int, n+1)
+	f := make([]int, m)
+	for pos := range n + 1 {
+		// f[i] is the completion of the inserted job on the machine i
+		makespan := 0
+		for i := range m {
+			prev := 0
+			if i > 0 {
+				prev = f[i-1]
+			}
+			f[i] = max(e[pos][i], prev) + p[i][job]
+			tail := 0
+			if pos < n {
+				tail = q[pos][i]
+			}
+			makespan = max(makespan, f[i]+tail)
+		}
+		makespans[pos] = makespan
+	}
+	return makespans
+}
+
+// Hash returns the hash of the sequence.
+func (s *State) Hash() uint64 {
+	// FNV-1a
+	h := uint64(14695981039346656037)
+	for _, job := range s.sequence {
+		h ^= uint64(job)
+		h *= 1099511628211
+	}
+	return h
+}
+
+func (s *State) Fingerprint() uint64 {
+	return s.Hash()
+}
@@ -0,0 +1,98 @@
+// Package pfsp solves the permutation flow shop scheduling problem (minimization of the makespan)
+// with ALNS.
+package pfsp
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Instance is a PFSP instance.
+type Instance struct {
+	Jobs       int
+	Machines   int
+	Seed       int     // the seed of Taillard's generator
+	UpperBound int     // the best known makespan
+	LowerBound int     // the lower bound of the makespan
+	Times      [][]int // the processing times, Times[machine][job]
+}
+
+// ReadFile reads the instances from a file in Taillard's format.
+func ReadFile(name string) ([]*Instance, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Parse(f)
+}
+
+// Parse parses the instances in Taillard's format. Every instance consists of a title line,
+// the line "jobs machines seed upper-bound lower-bound", the line "processing times :" and one
+// row of processing times per machine.
+func Parse(r io.Reader) ([]*Instance, error) {
+	var instances []*Instance
+	var instance *Instance
+	var numbers []int
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "":
+		case strings.HasPrefix(line, "number of jobs"):
+			if instance != nil || len(numbers) > 0 {
+				return nil, fmt.Errorf("instance %d: incomplete", len(instances)+1)
+			}
+		case strings.HasPrefix(line, "processing times"):
+		default:
+			for _, field := range strings.Fields(line) {
+				v, err := strconv.Atoi(field)
+				if err != nil {
+					return nil, fmt.Errorf("instance %d: %w", len(instances)+1, err)
+				}
+				numbers = append(numbers, v)
+			}
+		}
+
+		if instance == nil && len(numbers) >= 5 {
+			instance = &Instance{
+				Jobs:       numbers[0],
+				Machines:   numbers[1],
+				Seed:       numbers[2],
+				UpperBound: numbers[3],
+				LowerBound: numbers[4],
+			}
+			if instance.Jobs <= 0 || instance.Machines <= 0 || len(numbers) > 5 {
+				return nil, fmt.Errorf("instance %d: invalid header", len(instances)+1)
+			}
+			numbers = numbers[:0]
+		}
+		if instance != nil && len(numbers) >= instance.Jobs*instance.Machines {
+			if len(numbers) > instance.Jobs*instance.Machines {
+				return nil, fmt.Errorf("instance %d: too many processing times", len(instances)+1)
+			}
+			instance.Times = make([][]int, instance.Machines)
+			for i := range instance.Times {
+				instance.Times[i] = append([]int(nil), numbers[i*instance.Jobs:(i+1)*instance.Jobs]...)
+			}
+			instances = append(instances, instance)
+			instance = nil
+			numbers = numbers[:0]
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if instance != nil || len(numbers) > 0 {
+		return nil, fmt.Errorf("instance %d: incomplete", len(instances)+1)
+	}
+	if len(instances) == 0 {
+		return nil, fmt.Errorf("no instances")
+	}
+	return instances, nil
+}
@@ -0,0 +1,76 @@
+package tsp
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/bibenga/alns"
+)
+
+func BenchmarkCloneObjective(b *testing.B) {
+	problem := loadProblem(b)
+	state := initialState(b, problem, rand.New(rand.NewPCG(1, 2)))
+	for b.Loop() {
+		state.Clone().Objective()
+	}
+}
+
+func BenchmarkDestroy(b *testing.B) {
+	problem := loadProblem(b)
+	rnd := rand.New(rand.NewPCG(1, 2))
+	initial := initialState(b, problem, rnd)
+	for name, destroyOp := range map[string]alns.Operator{
+		"RandomRemoval": RandomRemoval(0.1),
+		"PathRemoval":   PathRemoval(0.1),
+		"WorstRemoval":  WorstRemoval(0.1),
+		"ShawRemoval":   ShawRemoval(0.1, 6),
+	} {
+		b.Run(name, func(b *testing.B) {
+			for b.Loop() {
+				destroyOp(initial, rnd)
+			}
+		})
+	}
+}
+
+func BenchmarkRepair(b *testing.B) {
+	problem := loadProblem(b)
+	rnd := rand.New(rand.NewPCG(1, 2))
+	destroyed, err := RandomRemoval(0.1)(initialState(b, problem, rnd), rnd)
+	if err != nil {
+		b.Fatal(err)
+	}
+	regretInsertion, err := alns.RegretInsertion(3, 0, 0)
+	if err != nil {
+		b.Fatal(err)
+	}
+	for name, repairOp := range map[string]alns.Operator{
+		"GreedyRepair":    GreedyRepair,
+		"RegretRepair":    RegretRepair,
+		"RegretInsertion": regretInsertion,
+	} {
+		b.Run(name, func(b *testing.B) {
+			for b.Loop() {
+				// the package repair operators insert into their input
+				repairOp(destroyed.(*State).Clone(), rnd)
+			}
+		})
+	}
+}
+
+func BenchmarkLocalSearch(b *testing.B) {
+	problem := loadProblem(b)
+	rnd := rand.New(rand.NewPCG(1, 2))
+	initial := initialState(b, problem, rnd)
+	for name, localSearch := range map[string]alns.LocalSearch{
+		"TwoOpt": TwoOpt,
+		"OrOpt":  OrOpt,
+	} {
+		b.Run(name, func(b *testing.B) {
+			for b.Loop() {
+				// the local searches improve their input
+				localSearch(initial.Clone(), rnd)
+			}
+		})
+	}
+}
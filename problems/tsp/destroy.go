@@ -0,0 +1,109 @@
+package tsp
+
+import (
+	"cmp"
+	"math"
+	"math/rand/v2"
+	"slices"
+
+	"github.com/bibenga/alns"
+)
+
+// nodesToRemove returns the number of nodes removed by the destroy operators for the degree of destruction.
+func nodesToRemove(state *State, degree float64) int {
+	return min(max(int(float64(state.Len())*degree), 1), state.Len())
+}
+
+// RandomRemoval removes random nodes.
+func RandomRemoval(degree float64) alns.Operator {
+	return func(state alns.State, rnd *rand.Rand) (alns.State, error) {
+		destroyed := state.(*State).Clone()
+		for range nodesToRemove(destroyed, degree) {
+			destroyed.Remove(randomAssigned(destroyed, rnd))
+		}
+		return destroyed, nil
+	}
+}
+
+// PathRemoval removes consecutive nodes starting from a random node.
+func PathRemoval(degree float64) alns.Operator {
+	return func(state alns.State, rnd *rand.Rand) (alns.State, error) {
+		destroyed := state.(*State).Clone()
+		toRemove := nodesToRemove(destroyed, degree)
+		if toRemove == 0 {
+			return destroyed, nil
+		}
+		node := randomAssigned(destroyed, rnd)
+		for range toRemove {
+			next := destroyed.Next(node)
+			destroyed.Remove(node)
+			node = next
+		}
+		return destroyed, nil
+	}
+}
+
+// WorstRemoval removes the nodes with the largest removal gain.
+func WorstRemoval(degree float64) alns.Operator {
+	return func(state alns.State, rnd *rand.Rand) (alns.State, error) {
+		destroyed := state.(*State).Clone()
+		d := destroyed.problem.Dists
+		for range nodesToRemove(destroyed, degree) {
+			worst, worstGain := unassigned, math.Inf(-1)
+			for node := range destroyed.next {
+				if !destroyed.IsAssigned(node) {
+					continue
+				}
+				prev, next := destroyed.Prev(node), destroyed.Next(node)
+				gain := d[prev][node] + d[node][next] - d[prev][next]
+				if gain > worstGain {
+					worst, worstGain = node, gain
+				}
+			}
+			destroyed.Remove(worst)
+		}
+		return destroyed, nil
+	}
+}
+
+// ShawRemoval removes related (close) nodes, see Ropke & Pisinger (2006). The determinism
+// parameter p >= 1 controls the randomness, the larger p the more the closest nodes are preferred.
+func ShawRemoval(degree float64, p float64) alns.Operator {
+	return func(state alns.State, rnd *rand.Rand) (alns.State, error) {
+		destroyed := state.(*State).Clone()
+		d := destroyed.problem.Dists
+		toRemove := nodesToRemove(destroyed, degree)
+		if toRemove == 0 {
+			return destroyed, nil
+		}
+
+		removed := []int{randomAssigned(destroyed, rnd)}
+		destroyed.Remove(removed[0])
+		candidates := make([]int, 0, destroyed.Len())
+		for len(removed) < toRemove {
+			r := removed[rnd.IntN(len(removed))]
+			candidates = candidates[:0]
+			for node := range destroyed.next {
+				if destroyed.IsAssigned(node) {
+					candidates = append(candidates, node)
+				}
+			}
+			slices.SortFunc(candidates, func(a, b int) int {
+				return cmp.Compare(d[r][a], d[r][b])
+			})
+			node := candidates[int(math.Pow(rnd.Float64(), p)*float64(len(candidates)))]
+			destroyed.Remove(node)
+			removed = append(removed, node)
+		}
+		return destroyed, nil
+	}
+}
+
+func randomAssigned(state *State, rnd *rand.Rand) int {
+	for {
+		node := rnd.IntN(len(state.next))
+		if state.IsAssigned(node) {
+			return node
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package tsp
+
+import (
+	"math/rand/v2"
+	"slices"
+
+	"github.com/bibenga/alns"
+)
+
+const epsilon = 1e-9
+
+// TwoOpt improves the tour by reversing segments until no improving 2-opt move exists.
+// The cached objective is recomputed from scratch.
+func TwoOpt(state alns.State, rnd *rand.Rand) (alns.State, error) {
+	s := state.(*State)
+	tour := s.Tour()
+	n := len(tour)
+	d := s.problem.Dists
+
+	for improved := true; improved; {
+		improved = false
+		for i := 0; i < n-2; i++ {
+			for j := i + 2; j < n; j++ {
+				if i == 0 && j == n-1 {
+					// both edges share the node tour[0]
+					continue
+				}
+				a, b := tour[i], tour[i+1]
+				c, e := tour[j], tour[(j+1)%n]
+				delta := d[a][c] + d[b][e] - d[a][b] - d[c][e]
+				if delta < -epsilon {
+					slices.Reverse(tour[i+1 : j+1])
+					improved = true
+				}
+			}
+		}
+	}
+
+	for i, node := range tour {
+		s.next[node] = tour[(i+1)%n]
+	}
+	s.rebuild()
+	return s, nil
+}
+
+// OrOpt improves the tour by moving segments of 1, 2 or 3 consecutive nodes to a better position
+// until no improving move exists.
+func OrOpt(state alns.State, rnd *rand.Rand) (alns.State, error) {
+	s := state.(*State)
+	d := s.problem.Dists
+
+	for improved := true; improved; {
+		improved = false
+		for length := 1; length <= 3; length++ {
+			if length >= s.size-1 {
+				break
+			}
+			for first := range s.next {
+				if !s.IsAssigned(first) {
+					continue
+				}
+				last := first
+				for range length - 1 {
+					last = s.next[last]
+				}
+				prev, next := s.prev[first], s.next[last]
+				gain := d[prev][first] + d[last][next] - d[prev][next]
+
+				for a := next; a != prev; a = s.next[a] {
+					b := s.next[a]
+					cost := d[a][first] + d[last][b] - d[a][b]
+					if cost-gain < -epsilon {
+						s.next[prev] = next
+						s.prev[next] = prev
+						s.next[a] = first
+						s.prev[first] = a
+						s.next[last] = b
+						s.prev[b] = last
+						s.objective += cost - gain
+						improved = true
+						break
+					}
+				}
+			}
+		}
+	}
+	return s, nil
+}
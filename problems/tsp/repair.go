@@ -0,0 +1,66 @@
+package tsp
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+
+	"github.com/bibenga/alns"
+)
+
+// GreedyRepair inserts the removed nodes in random order, each at its cheapest position.
+func GreedyRepair(state alns.State, rnd *rand.Rand) (alns.State, error) {
+	repaired := state.(*State)
+	nodes := slices.Clone(repaired.Removed())
+	rnd.Shuffle(len(nodes), func(i, j int) {
+		nodes[i], nodes[j] = nodes[j], nodes[i]
+	})
+	for _, node := range nodes {
+		after, _, _ := bestInsertions(repaired, node)
+		repaired.Insert(node, after)
+	}
+	return repaired, nil
+}
+
+// RegretRepair inserts the removed nodes in the order of the largest regret, i.e. the difference
+// between the cost of the best and the second best insertion position.
+func RegretRepair(state alns.State, rnd *rand.Rand) (alns.State, error) {
+	repaired := state.(*State)
+	for len(repaired.Removed()) > 0 {
+		bestNode, bestAfter, bestRegret := unassigned, unassigned, math.Inf(-1)
+		for _, node := range repaired.Removed() {
+			after, cost, secondCost := bestInsertions(repaired, node)
+			regret := secondCost - cost
+			if math.IsInf(regret, 1) {
+				// there is only one insertion position
+				regret = -cost
+			}
+			if regret > bestRegret || (regret == bestRegret && rnd.IntN(2) == 0) {
+				bestNode, bestAfter, bestRegret = node, after, regret
+			}
+		}
+		repaired.Insert(bestNode, bestAfter)
+	}
+	return repaired, nil
+}
+
+// bestInsertions returns the node after which the cheapest insertion is, its cost and the cost of
+// the second cheapest insertion.
+func bestInsertions(state *State, node int) (int, float64, float64) {
+	best, bestCost, secondCost := unassigned, math.Inf(1), math.Inf(1)
+	if state.Len() == 0 {
+		return unassigned, 0, math.Inf(1)
+	}
+	for after := range state.next {
+		if !state.IsAssigned(after) {
+			continue
+		}
+		cost := state.InsertCost(node, after)
+		if cost < bestCost {
+			best, bestCost, secondCost = after, cost, bestCost
+		} else if cost < secondCost {
+			secondCost = cost
+		}
+	}
+	return best, bestCost, secondCost
+}
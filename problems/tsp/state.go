@@ -0,0 +1,255 @@
+// Package tsp solves the symmetric travelling salesman problem with ALNS.
+//
+// The tour is stored as successor and predecessor arrays, so that removing and inserting a node,
+// including the update of the cached objective, takes O(1) and cloning a state only copies two arrays.
+package tsp
+
+import (
+	"fmt"
+
+	"github.com/bibenga/alns"
+)
+
+// Problem is a TSP instance defined by a symmetric distance matrix.
+type Problem struct {
+	Dists [][]float64
+}
+
+func NewProblem(dists [][]float64) *Problem {
+	return &Problem{
+		Dists: dists,
+	}
+}
+
+// Size returns the number of nodes.
+func (p *Problem) Size() int {
+	return len(p.Dists)
+}
+
+const unassigned = -1
+
+// State is a tour over the assigned nodes, the removed nodes are inserted back by the repair operators.
+type State struct {
+	problem   *Problem
+	next      []int // the successor of each node or unassigned
+	prev      []int // the predecessor of each node or unassigned
+	removed   []int // the unassigned nodes
+	size      int   // the number of assigned nodes
+	objective float64
+}
+
+var (
+	_ alns.State          = &State{}
+	_ alns.Hasher         = &State{}
+	_ alns.Fingerprinter  = &State{}
+	_ alns.ElementRemover = &State{}
+	_ alns.Insertable     = &State{}
+)
+
+// NewState creates an empty tour, all nodes are unassigned.
+func NewState(problem *Problem) *State {
+	n := problem.Size()
+	s := State{
+		problem: problem,
+		next:    make([]int, n),
+		prev:    make([]int, n),
+		removed: make([]int, n),
+	}
+	for i := range n {
+		s.next[i] = unassigned
+		s.prev[i] = unassigned
+		s.removed[i] = i
+	}
+	return &s
+}
+
+// NewStateFromTour creates a state from a complete tour.
+func NewStateFromTour(problem *Problem, tour []int) (*State, error) {
+	if len(tour) != problem.Size() {
+		return nil, fmt.Errorf("%d nodes expected, actual %d", problem.Size(), len(tour))
+	}
+	s := NewState(problem)
+	s.removed = s.removed[:0]
+	for i, node := range tour {
+		if node < 0 || node >= problem.Size() || s.next[node] != unassigned {
+			return nil, fmt.Errorf("invalid node %d", node)
+		}
+		s.next[node] = tour[(i+1)%len(tour)]
+	}
+	s.rebuild()
+	return s, nil
+}
+
+// rebuild restores prev, size and objective from next.
+func (s *State) rebuild() {
+	s.size = 0
+	s.objective = 0
+	for node, next := range s.next {
+		if next != unassigned {
+			s.prev[next] = node
+			s.size++
+			s.objective += s.problem.Dists[node][next]
+		}
+	}
+}
+
+func (s *State) Clone() *State {
+	return &State{
+		problem:   s.problem,
+		next:      append([]int(nil), s.next...),
+		prev:      append([]int(nil), s.prev...),
+		removed:   append([]int(nil), s.removed...),
+		size:      s.size,
+		objective: s.objective,
+	}
+}
+
+// Objective returns the length of the closed tour over the assigned nodes.
+func (s *State) Objective() float64 {
+	return s.objective
+}
+
+func (s *State) Problem() *Problem {
+	return s.problem
+}
+
+// Removed returns the unassigned nodes.
+func (s *State) Removed() []int {
+	return s.removed
+}
+
+// Unassigned returns the removed nodes, it is the same as Removed.
+func (s *State) Unassigned() []int {
+	return s.removed
+}
+
+// Insertions returns the insertion of the removed node after every assigned node.
+func (s *State) Insertions(node int) []alns.Insertion {
+	if s.size == 0 {
+		return []alns.Insertion{{Position: unassigned, Cost: 0}}
+	}
+	insertions := make([]alns.Insertion, 0, s.size)
+	for after, next := range s.next {
+		if next != unassigned {
+			insertions = append(insertions, alns.Insertion{Position: after, Cost: s.InsertCost(node, after)})
+		}
+	}
+	return insertions
+}
+
+// Len returns the number of assigned nodes.
+func (s *State) Len() int {
+	return s.size
+}
+
+// Assigned returns the assigned nodes.
+func (s *State) Assigned() []int {
+	assigned := make([]int, 0, s.size)
+	for node, next := range s.next {
+		if next != unassigned {
+			assigned = append(assigned, node)
+		}
+	}
+	return assigned
+}
+
+func (s *State) CloneRemover() alns.ElementRemover {
+	return s.Clone()
+}
+
+func (s *State) CloneInsertable() alns.Insertable {
+	return s.Clone()
+}
+
+func (s *State) IsAssigned(node int) bool {
+	return s.next[node] != unassigned
+}
+
+func (s *State) Next(node int) int {
+	return s.next[node]
+}
+
+func (s *State) Prev(node int) int {
+	return s.prev[node]
+}
+
+// Tour returns the assigned nodes in the visiting order starting from the smallest node.
+func (s *State) Tour() []int {
+	tour := make([]int, 0, s.size)
+	start := unassigned
+	for node, next := range s.next {
+		if next != unassigned {
+			start = node
+			break
+		}
+	}
+	if start == unassigned {
+		return tour
+	}
+	node := start
+	for {
+		tour = append(tour, node)
+		node = s.next[node]
+		if node == start {
+			return tour
+		}
+	}
+}
+
+// Remove unassigns the node.
+func (s *State) Remove(node int) {
+	prev, next := s.prev[node], s.next[node]
+	d := s.problem.Dists
+	s.objective += d[prev][next] - d[prev][node] - d[node][next]
+	s.next[prev] = next
+	s.prev[next] = prev
+	s.next[node] = unassigned
+	s.prev[node] = unassigned
+	s.removed = append(s.removed, node)
+	s.size--
+}
+
+// InsertCost returns the change of the objective if the node is inserted after the assigned node.
+func (s *State) InsertCost(node, after int) float64 {
+	d := s.problem.Dists
+	next := s.next[after]
+	return d[after][node] + d[node][next] - d[after][next]
+}
+
+// Insert assigns the removed node after the assigned node (after is ignored if the tour is empty).
+func (s *State) Insert(node, after int) {
+	if s.size == 0 {
+		s.next[node] = node
+		s.prev[node] = node
+	} else {
+		s.objective += s.InsertCost(node, after)
+		next := s.next[after]
+		s.next[after] = node
+		s.prev[node] = after
+		s.next[node] = next
+		s.prev[next] = node
+	}
+	s.size++
+	for i, removed := range s.removed {
+		if removed == node {
+			s.removed[i] = s.removed[len(s.removed)-1]
+			s.removed = s.removed[:len(s.removed)-1]
+			break
+		}
+	}
+}
+
+// Hash returns the hash of the successor array.
+func (s *State) Hash() uint64 {
+	// FNV-1a
+	h := uint64(14695981039346656037)
+	for _, next := range s.next {
+		h ^= uint64(next)
+		h *= 1099511628211
+	}
+	return h
+}
+
+func (s *State) Fingerprint() uint64 {
+	return s.Hash()
+}
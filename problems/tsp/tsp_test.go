@@ -0,0 +1,363 @@
+package tsp
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/bibenga/alns"
+	"github.com/bibenga/alns/tsplib"
+)
+
+const xqf131Optimal = 564
+
+func loadProblem(t testing.TB) *Problem {
+	instance, err := tsplib.ReadFile("testdata/xqf131.tsp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewProblem(instance.Distances())
+}
+
+func initialState(t testing.TB, problem *Problem, rnd *rand.Rand) *State {
+	state, err := GreedyRepair(NewState(problem), rnd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return state.(*State)
+}
+
+// circleProblem places n nodes on a circle in the order of their numbers, so the optimal tour
+// visits them in this order and every tour without crossing edges is optimal.
+func circleProblem(n int) (problem *Problem, optimal float64) {
+	coords := make([][2]float64, n)
+	for i := range coords {
+		angle := 2 * math.Pi * float64(i) / float64(n)
+		coords[i] = [2]float64{10 * math.Cos(angle), 10 * math.Sin(angle)}
+	}
+	dists := make([][]float64, n)
+	for i, a := range coords {
+		dists[i] = make([]float64, n)
+		for j, b := range coords {
+			dists[i][j] = math.Hypot(a[0]-b[0], a[1]-b[1])
+		}
+	}
+	return NewProblem(dists), float64(n) * 20 * math.Sin(math.Pi/float64(n))
+}
+
+// checkTour checks that the successors and the predecessors form a single cycle over the assigned
+// nodes, that the removed nodes are exactly the other nodes and that the cached objective is the
+// length of the cycle.
+func checkTour(t *testing.T, s *State) {
+	t.Helper()
+	n := s.problem.Size()
+	assigned := 0
+	for node := range n {
+		if s.IsAssigned(node) {
+			assigned++
+			if s.Prev(s.Next(node)) != node {
+				t.Fatalf("the predecessor of the successor of %d is %d", node, s.Prev(s.Next(node)))
+			}
+		} else if s.Prev(node) != unassigned || !slices.Contains(s.Removed(), node) {
+			t.Fatalf("the unassigned node %d is linked or not removed", node)
+		}
+	}
+	if assigned != s.Len() || assigned+len(s.Removed()) != n {
+		t.Fatalf("%d assigned and %d removed nodes, the length %d", assigned, len(s.Removed()), s.Len())
+	}
+	tour := s.Tour()
+	if len(tour) != assigned {
+		t.Fatalf("a single cycle over %d nodes expected, actual %d nodes", assigned, len(tour))
+	}
+	length := 0.0
+	for i, node := range tour {
+		length += s.problem.Dists[node][tour[(i+1)%len(tour)]]
+	}
+	if math.Abs(length-s.Objective()) > 1e-6 {
+		t.Fatalf("the cached objective %f differs from the tour length %f", s.Objective(), length)
+	}
+}
+
+func TestNewStateFromTour(t *testing.T) {
+	problem, optimal := circleProblem(4)
+	s, err := NewStateFromTour(problem, []int{0, 1, 2, 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkTour(t, s)
+	if math.Abs(s.Objective()-optimal) > 1e-9 {
+		t.Fatalf("objective %f expected, actual %f", optimal, s.Objective())
+	}
+	for name, tour := range map[string][]int{
+		"short":     {0, 1, 2},
+		"duplicate": {0, 1, 1, 3},
+		"range":     {0, 1, 2, 4},
+	} {
+		if _, err := NewStateFromTour(problem, tour); err == nil {
+			t.Errorf("%s: invalid tour error expected", name)
+		}
+	}
+}
+
+func TestRemoveInsert(t *testing.T) {
+	problem := loadProblem(t)
+	rnd := rand.New(rand.NewPCG(1, 2))
+	s, err := NewStateFromTour(problem, rnd.Perm(problem.Size()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash, objective := s.Hash(), s.Objective()
+
+	// removing and inserting after the former predecessor restores the tour
+	for range 200 {
+		node := rnd.IntN(problem.Size())
+		prev := s.Prev(node)
+		s.Remove(node)
+		checkTour(t, s)
+		s.Insert(node, prev)
+	}
+	checkTour(t, s)
+	if s.Hash() != hash || math.Abs(s.Objective()-objective) > 1e-6 {
+		t.Fatal("the tour is expected to be restored")
+	}
+
+	// the cost of an insertion is the change of the objective, down to the empty tour and back
+	for s.Len() > 0 {
+		s.Remove(s.Tour()[rnd.IntN(s.Len())])
+		checkTour(t, s)
+	}
+	for len(s.Removed()) > 0 {
+		node := s.Removed()[rnd.IntN(len(s.Removed()))]
+		after := unassigned
+		if s.Len() > 0 {
+			after = s.Tour()[rnd.IntN(s.Len())]
+		}
+		before, cost := s.Objective(), 0.0
+		if after != unassigned {
+			cost = s.InsertCost(node, after)
+		}
+		s.Insert(node, after)
+		checkTour(t, s)
+		if math.Abs(s.Objective()-before-cost) > 1e-6 {
+			t.Fatalf("the insertion cost %f differs from the change %f", cost, s.Objective()-before)
+		}
+	}
+}
+
+func TestDestroy(t *testing.T) {
+	problem := loadProblem(t)
+	rnd := rand.New(rand.NewPCG(1, 2))
+	initial := initialState(t, problem, rnd)
+	d := problem.Dists
+
+	t.Run("PathRemoval", func(t *testing.T) {
+		destroyed, _ := PathRemoval(0.1)(initial, rnd)
+		removed := destroyed.(*State).Removed()
+		// the removed nodes follow each other in the original tour
+		for i := 1; i < len(removed); i++ {
+			if initial.Next(removed[i-1]) != removed[i] {
+				t.Fatalf("the removed nodes %v are not consecutive", removed)
+			}
+		}
+	})
+
+	t.Run("WorstRemoval", func(t *testing.T) {
+		destroyed, _ := WorstRemoval(0.001)(initial, rnd)
+		removed := destroyed.(*State).Removed()[0]
+		gain := func(node int) float64 {
+			prev, next := initial.Prev(node), initial.Next(node)
+			return d[prev][node] + d[node][next] - d[prev][next]
+		}
+		for node := range problem.Size() {
+			if gain(node) > gain(removed) {
+				t.Fatalf("the node %d has a larger gain than the removed node %d", node, removed)
+			}
+		}
+	})
+
+	t.Run("ShawRemoval", func(t *testing.T) {
+		// with a very large p every removed node is the nearest assigned node to a removed one
+		destroyed, _ := ShawRemoval(0.02, 1e9)(initial, rnd)
+		removed := destroyed.(*State).Removed()
+		for i := 1; i < len(removed); i++ {
+			nearest := false
+			for _, r := range removed[:i] {
+				closer := false
+				for node := range problem.Size() {
+					if !slices.Contains(removed[:i], node) && d[r][node] < d[r][removed[i]] {
+						closer = true
+						break
+					}
+				}
+				nearest = nearest || !closer
+			}
+			if !nearest {
+				t.Fatalf("the node %d is not the nearest neighbour of a removed node %v", removed[i], removed[:i])
+			}
+		}
+	})
+
+	for name, destroyOp := range map[string]alns.Operator{
+		"RandomRemoval": RandomRemoval(0.1),
+		"PathRemoval":   PathRemoval(0.1),
+		"WorstRemoval":  WorstRemoval(0.1),
+		"ShawRemoval":   ShawRemoval(0.1, 6),
+	} {
+		fingerprint := initial.Fingerprint()
+		destroyed, err := destroyOp(initial, rnd)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if initial.Fingerprint() != fingerprint {
+			t.Fatalf("%s: the input state was mutated", name)
+		}
+		checkTour(t, destroyed.(*State))
+		if removed := len(destroyed.(*State).Removed()); removed != 13 {
+			t.Fatalf("%s: 13 removed nodes (10%% of 131) expected, actual %d", name, removed)
+		}
+	}
+}
+
+func TestDestroyEmptyTour(t *testing.T) {
+	empty := NewState(NewProblem([][]float64{{0, 1}, {1, 0}}))
+	for name, destroyOp := range map[string]alns.Operator{
+		"RandomRemoval": RandomRemoval(0.5),
+		"PathRemoval":   PathRemoval(0.5),
+		"WorstRemoval":  WorstRemoval(0.5),
+		"ShawRemoval":   ShawRemoval(0.5, 6),
+	} {
+		destroyed, err := destroyOp(empty, rand.New(rand.NewPCG(1, 2)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(destroyed.(*State).Removed()) != 2 {
+			t.Fatalf("%s: the empty tour expected to stay empty", name)
+		}
+	}
+}
+
+func TestRepair(t *testing.T) {
+	problem, optimal := circleProblem(12)
+	regretInsertion, err := alns.RegretInsertion(3, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, repairOp := range map[string]alns.Operator{
+		"GreedyRepair":    GreedyRepair,
+		"RegretRepair":    RegretRepair,
+		"RegretInsertion": regretInsertion,
+	} {
+		t.Run(name, func(t *testing.T) {
+			rnd := rand.New(rand.NewPCG(1, 2))
+			// every other node is removed, the cheapest position of each is between its neighbours
+			s, _ := NewStateFromTour(problem, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11})
+			for node := 1; node < 12; node += 2 {
+				s.Remove(node)
+			}
+			repaired, err := repairOp(s, rnd)
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkTour(t, repaired.(*State))
+			if math.Abs(repaired.Objective()-optimal) > 1e-9 {
+				t.Fatalf("the optimal tour %f expected, actual %f", optimal, repaired.Objective())
+			}
+
+			// a tour is built from scratch
+			repaired, err = repairOp(NewState(problem), rnd)
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkTour(t, repaired.(*State))
+			if len(repaired.(*State).Removed()) != 0 {
+				t.Fatal("all nodes are expected to be inserted")
+			}
+		})
+	}
+}
+
+func TestTwoOpt(t *testing.T) {
+	// a tour of nodes on a circle without crossing edges is optimal
+	problem, optimal := circleProblem(20)
+	rnd := rand.New(rand.NewPCG(1, 2))
+	for range 10 {
+		s, _ := NewStateFromTour(problem, rnd.Perm(problem.Size()))
+		improved, err := TwoOpt(s, rnd)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkTour(t, improved.(*State))
+		if math.Abs(improved.Objective()-optimal) > 1e-9 {
+			t.Fatalf("the optimal tour %f expected, actual %f", optimal, improved.Objective())
+		}
+	}
+}
+
+func TestOrOpt(t *testing.T) {
+	problem := loadProblem(t)
+	d := problem.Dists
+	rnd := rand.New(rand.NewPCG(1, 2))
+	s, _ := NewStateFromTour(problem, rnd.Perm(problem.Size()))
+	before := s.Objective()
+	improved, err := OrOpt(s, rnd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s = improved.(*State)
+	checkTour(t, s)
+	if s.Objective() >= before {
+		t.Fatalf("the random tour %f is expected to be improved, actual %f", before, s.Objective())
+	}
+
+	// no segment of 1 to 3 nodes can be moved to a better position
+	tour := s.Tour()
+	n := len(tour)
+	for length := 1; length <= 3; length++ {
+		for i := range n {
+			first, last := tour[i], tour[(i+length-1)%n]
+			prev, next := tour[(i+n-1)%n], tour[(i+length)%n]
+			gain := d[prev][first] + d[last][next] - d[prev][next]
+			for j := range n - length - 1 {
+				a, b := tour[(i+length+j)%n], tour[(i+length+j+1)%n]
+				if d[a][first]+d[last][b]-d[a][b] < gain-epsilon {
+					t.Fatalf("moving %d..%d between %d and %d improves the tour", first, last, a, b)
+				}
+			}
+		}
+	}
+}
+
+func TestSolve(t *testing.T) {
+	problem := loadProblem(t)
+	rnd := rand.New(rand.NewPCG(12, 34))
+	initial := initialState(t, problem, rnd)
+
+	a := alns.ALNS{
+		Rnd: rnd,
+		DestroyOperators: []alns.Operator{
+			RandomRemoval(0.1),
+			PathRemoval(0.1),
+			WorstRemoval(0.1),
+			ShawRemoval(0.1, 6),
+		},
+		RepairOperators: []alns.Operator{GreedyRepair, RegretRepair},
+		LocalSearches:   []alns.LocalSearch{OrOpt},
+		CheckOperators:  true,
+	}
+	selector, err := alns.NewRouletteWheel([4]float64{3, 2, 1, 0.5}, 0.8, 4, 2, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	accept := alns.HillClimbing{}
+	stop := alns.MaxIterations{MaxIterations: 1000}
+	result, err := a.Iterate(initial, &selector, &accept, &stop)
+	if err != nil {
+		t.Fatal(err)
+	}
+	best := result.BestState.(*State)
+	checkTour(t, best)
+	if best.Objective() > 1.1*xqf131Optimal {
+		t.Fatalf("a solution within 10%% of the optimum %d expected, actual %f", xqf131Optimal, best.Objective())
+	}
+}
@@ -0,0 +1,101 @@
+package vrptw
+
+import (
+	"cmp"
+	"math"
+	"math/rand/v2"
+	"slices"
+
+	"github.com/bibenga/alns"
+)
+
+// customersToRemove returns the number of customers removed by the destroy operators for the degree of destruction.
+func customersToRemove(state *State, degree float64) int {
+	n := state.instance.Size() - 1 - len(state.Unassigned())
+	return min(max(int(float64(n)*degree), 1), n)
+}
+
+// RandomRemoval removes random customers.
+func RandomRemoval(degree float64) alns.Operator {
+	return func(state alns.State, rnd *rand.Rand) (alns.State, error) {
+		destroyed := state.(*State).Clone()
+		for range customersToRemove(destroyed, degree) {
+			destroyed.Remove(randomAssigned(destroyed, rnd))
+		}
+		return destroyed, nil
+	}
+}
+
+// TimeRemoval removes customers whose service starts at a similar time, it is the time related
+// removal of Pisinger & Ropke (2007). The determinism parameter p >= 1 controls the randomness,
+// the larger p the more the most related customers are preferred.
+func TimeRemoval(degree float64, p float64) alns.Operator {
+	return func(state alns.State, rnd *rand.Rand) (alns.State, error) {
+		destroyed := state.(*State).Clone()
+		toRemove := customersToRemove(destroyed, degree)
+		if toRemove == 0 {
+			return destroyed, nil
+		}
+
+		// the schedule is taken before the removal, the start times change when customers are removed
+		start := make([]float64, destroyed.instance.Size())
+		candidates := make([]int, 0, len(start))
+		for customer := 1; customer < len(start); customer++ {
+			if destroyed.RouteOf(customer) != unassigned {
+				start[customer] = destroyed.Start(customer)
+				candidates = append(candidates, customer)
+			}
+		}
+
+		removed := []int{randomAssigned(destroyed, rnd)}
+		destroyed.Remove(removed[0])
+		for len(removed) < toRemove {
+			r := removed[rnd.IntN(len(removed))]
+			candidates = slices.DeleteFunc(candidates, func(c int) bool {
+				return destroyed.RouteOf(c) == unassigned
+			})
+			slices.SortFunc(candidates, func(a, b int) int {
+				return cmp.Compare(math.Abs(start[r]-start[a]), math.Abs(start[r]-start[b]))
+			})
+			customer := candidates[int(math.Pow(rnd.Float64(), p)*float64(len(candidates)))]
+			destroyed.Remove(customer)
+			removed = append(removed, customer)
+		}
+		return destroyed, nil
+	}
+}
+
+// RouteRemoval removes all customers of the route with the fewest customers, the repair operators
+// then try to serve them by the other vehicles.
+func RouteRemoval(state alns.State, rnd *rand.Rand) (alns.State, error) {
+	destroyed := state.(*State).Clone()
+	if destroyed.NumRoutes() == 0 {
+		return destroyed, nil
+	}
+	shortest, count := 0, 0
+	for r := range destroyed.NumRoutes() {
+		switch n, m := len(destroyed.Route(r)), len(destroyed.Route(shortest)); {
+		case n < m:
+			shortest, count = r, 1
+		case n == m:
+			// reservoir sampling of the shortest routes
+			count++
+			if rnd.IntN(count) == 0 {
+				shortest = r
+			}
+		}
+	}
+	for _, customer := range slices.Clone(destroyed.Route(shortest)) {
+		destroyed.Remove(customer)
+	}
+	return destroyed, nil
+}
+
+func randomAssigned(state *State, rnd *rand.Rand) int {
+	for {
+		customer := 1 + rnd.IntN(state.instance.Size()-1)
+		if state.RouteOf(customer) != unassigned {
+			return customer
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package vrptw
+
+import (
+	"cmp"
+	"math"
+	"math/rand/v2"
+	"slices"
+
+	"github.com/bibenga/alns"
+)
+
+// insertion is a position of a customer in a route, the route NumRoutes means a new route.
+type insertion struct {
+	route, pos int
+	cost       float64
+}
+
+// bestInsertion returns the cheapest feasible insertion of the customer into an existing route or
+// a new route if there is none.
+func bestInsertion(state *State, customer int) insertion {
+	best := insertion{route: state.NumRoutes(), cost: math.Inf(1)}
+	for r := range state.NumRoutes() {
+		for pos := range len(state.Route(r)) + 1 {
+			if !state.CanInsert(customer, r, pos) {
+				continue
+			}
+			if cost := state.InsertCost(customer, r, pos); cost < best.cost {
+				best = insertion{route: r, pos: pos, cost: cost}
+			}
+		}
+	}
+	return best
+}
+
+// GreedyRepair inserts the unassigned customers in random order, each at its cheapest feasible
+// position. A new route is opened only if no existing route can serve the customer.
+func GreedyRepair(state alns.State, rnd *rand.Rand) (alns.State, error) {
+	repaired := state.(*State)
+	customers := slices.Clone(repaired.Unassigned())
+	rnd.Shuffle(len(customers), func(i, j int) {
+		customers[i], customers[j] = customers[j], customers[i]
+	})
+	for _, customer := range customers {
+		best := bestInsertion(repaired, customer)
+		repaired.Insert(customer, best.route, best.pos)
+	}
+	return repaired, nil
+}
+
+// TimeRepair inserts the unassigned customers in the order of the beginning of their time windows
+// (the ties in the order of the end), each at its cheapest feasible position.
+func TimeRepair(state alns.State, rnd *rand.Rand) (alns.State, error) {
+	repaired := state.(*State)
+	in := repaired.instance
+	customers := slices.Clone(repaired.Unassigned())
+	slices.SortFunc(customers, func(a, b int) int {
+		return cmp.Or(cmp.Compare(in.Ready[a], in.Ready[b]), cmp.Compare(in.Due[a], in.Due[b]), cmp.Compare(a, b))
+	})
+	for _, customer := range customers {
+		best := bestInsertion(repaired, customer)
+		repaired.Insert(customer, best.route, best.pos)
+	}
+	return repaired, nil
+}
+
+// RegretRepair inserts the unassigned customers in the order of the largest regret-k value, i.e.
+// the sum of the differences between the cost of the best feasible insertion and the cost of the
+// best feasible insertions into the next k-1 routes. A new route counts as one more route. A customer
+// that fits into fewer than k routes is more urgent than the customers with more routes whatever
+// their regret.
+func RegretRepair(k int) alns.Operator {
+	k = max(k, 2)
+	return func(state alns.State, rnd *rand.Rand) (alns.State, error) {
+		repaired := state.(*State)
+		best := make([]insertion, 0, k)
+		for len(repaired.Unassigned()) > 0 {
+			var bestCustomer int
+			var bestInsertion insertion
+			bestMissing, bestRegret := -1, 0.0
+			for _, customer := range repaired.Unassigned() {
+				best = bestRouteInsertions(repaired, customer, k, best[:0])
+				// the fewer routes can serve the customer the more urgent it is
+				missing := k - len(best)
+				regret := 0.0
+				for _, other := range best[1:] {
+					regret += other.cost - best[0].cost
+				}
+				if c := cmp.Or(
+					cmp.Compare(missing, bestMissing),
+					cmp.Compare(regret, bestRegret),
+					cmp.Compare(bestInsertion.cost, best[0].cost),
+				); c > 0 {
+					bestCustomer, bestInsertion, bestMissing, bestRegret = customer, best[0], missing, regret
+				}
+			}
+			repaired.Insert(bestCustomer, bestInsertion.route, bestInsertion.pos)
+		}
+		return repaired, nil
+	}
+}
+
+// bestRouteInsertions returns the cheapest feasible insertions of the customer into the k best
+// routes ordered by the cost. A new route is one of the candidates, its cost includes the weight of
+// a vehicle, so it is the last choice.
+func bestRouteInsertions(state *State, customer, k int, best []insertion) []insertion {
+	candidates := append(best, insertion{
+		route: state.NumRoutes(),
+		cost:  state.InsertCost(customer, state.NumRoutes(), 0) + state.vehicleWeight,
+	})
+	for r := range state.NumRoutes() {
+		routeBest := insertion{route: r, cost: math.Inf(1)}
+		for pos := range len(state.Route(r)) + 1 {
+			if !state.CanInsert(customer, r, pos) {
+				continue
+			}
+			if cost := state.InsertCost(customer, r, pos); cost < routeBest.cost {
+				routeBest = insertion{route: r, pos: pos, cost: cost}
+			}
+		}
+		if !math.IsInf(routeBest.cost, 1) {
+			candidates = append(candidates, routeBest)
+		}
+	}
+	slices.SortFunc(candidates, func(a, b insertion) int {
+		return cmp.Compare(a.cost, b.cost)
+	})
+	return candidates[:min(k, len(candidates))]
+}
@@ -0,0 +1,135 @@
+// Package vrptw solves the vehicle routing problem with time windows with ALNS.
+//
+// The instances use the Solomon format, which is also used by the Gehring & Homberger instances.
+// The node 0 is the depot, the travel times are equal to the unrounded euclidean distances and
+// the solutions are written in the CVRPLIB format (see cvrp.Solution).
+package vrptw
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Instance is a VRPTW instance.
+type Instance struct {
+	Name     string
+	Vehicles int          // the number of available vehicles
+	Capacity int          // the vehicle capacity
+	Coords   [][2]float64 // the node coordinates
+	Demands  []int        // the customer demands, zero for the depot
+	Ready    []float64    // the earliest start of the service
+	Due      []float64    // the latest start of the service, for the depot the latest return
+	Service  []float64    // the service times
+	Dists    [][]float64  // the euclidean distances, equal to the travel times
+}
+
+// Depot is the index of the depot node.
+const Depot = 0
+
+// Size returns the number of nodes including the depot.
+func (instance *Instance) Size() int {
+	return len(instance.Coords)
+}
+
+// ReadFile reads an instance file in the Solomon format.
+func ReadFile(name string) (*Instance, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Parse(f)
+}
+
+// Parse parses an instance in the Solomon format: the name, the VEHICLE section with the number
+// of vehicles and the capacity and the CUSTOMER section with one row per node.
+func Parse(r io.Reader) (*Instance, error) {
+	instance := Instance{}
+	section := ""
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		switch {
+		case instance.Name == "":
+			instance.Name = fields[0]
+		case fields[0] == "VEHICLE" || fields[0] == "CUSTOMER":
+			section = fields[0]
+		case fields[0] == "NUMBER" || fields[0] == "CUST":
+			// the column titles
+		case section == "VEHICLE":
+			values, err := parseInts(fields, 2)
+			if err != nil {
+				return nil, fmt.Errorf("VEHICLE: %w", err)
+			}
+			instance.Vehicles, instance.Capacity = values[0], values[1]
+		case section == "CUSTOMER":
+			values, err := parseInts(fields, 7)
+			if err != nil {
+				return nil, fmt.Errorf("CUSTOMER: %w", err)
+			}
+			if values[0] != instance.Size() {
+				return nil, fmt.Errorf("CUSTOMER: the node %d expected, actual %d", instance.Size(), values[0])
+			}
+			instance.Coords = append(instance.Coords, [2]float64{float64(values[1]), float64(values[2])})
+			instance.Demands = append(instance.Demands, values[3])
+			instance.Ready = append(instance.Ready, float64(values[4]))
+			instance.Due = append(instance.Due, float64(values[5]))
+			instance.Service = append(instance.Service, float64(values[6]))
+		default:
+			return nil, fmt.Errorf("unexpected line %q", scanner.Text())
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if instance.Vehicles <= 0 || instance.Capacity <= 0 {
+		return nil, fmt.Errorf("invalid number of vehicles %d or capacity %d", instance.Vehicles, instance.Capacity)
+	}
+	if instance.Size() < 2 {
+		return nil, fmt.Errorf("no customers")
+	}
+
+	instance.Dists = make([][]float64, instance.Size())
+	for i, a := range instance.Coords {
+		instance.Dists[i] = make([]float64, instance.Size())
+		for j, b := range instance.Coords {
+			instance.Dists[i][j] = math.Hypot(a[0]-b[0], a[1]-b[1])
+		}
+	}
+	for i := 1; i < instance.Size(); i++ {
+		if instance.Demands[i] > instance.Capacity {
+			return nil, fmt.Errorf("the demand %d of the customer %d exceeds the capacity", instance.Demands[i], i)
+		}
+		start := max(instance.Ready[i], instance.Ready[Depot]+instance.Dists[Depot][i])
+		if start > instance.Due[i] || start+instance.Service[i]+instance.Dists[i][Depot] > instance.Due[Depot] {
+			return nil, fmt.Errorf("the customer %d cannot be served", i)
+		}
+	}
+	return &instance, nil
+}
+
+// parseInts parses a row of n integer columns (the values may be written as 10.0 in some files).
+func parseInts(fields []string, n int) ([]int, error) {
+	if len(fields) != n {
+		return nil, fmt.Errorf("%d columns expected, actual %d", n, len(fields))
+	}
+	values := make([]int, n)
+	for i, field := range fields {
+		v, err := strconv.ParseFloat(field, 64)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = int(v)
+	}
+	return values, nil
+}
@@ -0,0 +1,200 @@
+package vrptw
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/bibenga/alns"
+	"github.com/bibenga/alns/problems/cvrp"
+)
+
+const unassigned = -1 // the route of an unassigned customer, see cvrp.Routes.RouteOf
+
+// schedule is the schedule of a route cached for the positions 0 (the departure from the depot),
+// 1..len(customers) and len(customers)+1 (the return to the depot).
+type schedule struct {
+	start  []float64 // the earliest start of the service
+	latest []float64 // the latest start of the service that keeps the rest of the route feasible
+}
+
+// State is a set of routes (see cvrp.Routes) with time windows. For every route the forward
+// (earliest start) and backward (latest start) schedules are cached, so that the feasibility of an
+// insertion is checked in O(1). The schedules are updated in O(route length) after every change.
+//
+// The objective is lexicographic: the number of vehicles first and the distance second.
+type State struct {
+	cvrp.Routes
+	instance      *Instance
+	schedules     []schedule // the schedule of each route
+	vehicleWeight float64    // more than the largest possible difference of the distance
+}
+
+var (
+	_ alns.State          = &State{}
+	_ alns.Comparable     = &State{}
+	_ alns.MultiObjective = &State{}
+	_ alns.Feasibility    = &State{}
+	_ alns.Hasher         = &State{}
+	_ alns.Fingerprinter  = &State{}
+)
+
+// NewState creates a state without routes, all customers are unassigned.
+func NewState(instance *Instance) *State {
+	s := State{
+		Routes:        cvrp.NewRoutes(instance.Dists, instance.Demands),
+		instance:      instance,
+		vehicleWeight: 1,
+	}
+	for i := range instance.Size() {
+		if i != Depot {
+			// by the triangle inequality no solution is longer than serving every customer by its own route
+			s.vehicleWeight += 2 * instance.Dists[Depot][i]
+		}
+	}
+	return &s
+}
+
+// NewStateFromSolution creates a state from a complete and feasible solution.
+func NewStateFromSolution(instance *Instance, solution *cvrp.Solution) (*State, error) {
+	s := NewState(instance)
+	for _, customers := range solution.Routes {
+		if len(customers) == 0 {
+			continue
+		}
+		r := s.NumRoutes()
+		for i, customer := range customers {
+			if customer <= Depot || customer >= instance.Size() || s.RouteOf(customer) != unassigned {
+				return nil, fmt.Errorf("invalid customer %d", customer)
+			}
+			if !s.CanInsert(customer, r, i) {
+				return nil, fmt.Errorf("the customer %d cannot be served by the route %d", customer, r+1)
+			}
+			s.Insert(customer, r, i)
+		}
+	}
+	if len(s.Unassigned()) != 0 {
+		return nil, fmt.Errorf("%d customers are not visited", len(s.Unassigned()))
+	}
+	return s, nil
+}
+
+func (s *State) Clone() *State {
+	schedules := make([]schedule, len(s.schedules))
+	for i, sch := range s.schedules {
+		schedules[i] = schedule{
+			start:  slices.Clone(sch.start),
+			latest: slices.Clone(sch.latest),
+		}
+	}
+	return &State{
+		Routes:        s.Routes.Clone(),
+		instance:      s.instance,
+		schedules:     schedules,
+		vehicleWeight: s.vehicleWeight,
+	}
+}
+
+// Objective returns the number of vehicles multiplied by a weight larger than any distance plus
+// the distance, so that it orders the solutions the same way as Compare.
+func (s *State) Objective() float64 {
+	return float64(s.NumRoutes())*s.vehicleWeight + s.TotalCost()
+}
+
+// Objectives returns the number of vehicles and the distance.
+func (s *State) Objectives() []float64 {
+	return []float64{float64(s.NumRoutes()), s.TotalCost()}
+}
+
+// Compare compares the number of vehicles and then the distance.
+func (s *State) Compare(other alns.State) int {
+	return alns.CompareLexicographic(s.Objectives(), other.(*State).Objectives())
+}
+
+// Feasible reports whether all customers are served by the available vehicles.
+func (s *State) Feasible() bool {
+	return len(s.Unassigned()) == 0 && s.NumRoutes() <= s.instance.Vehicles
+}
+
+func (s *State) Instance() *Instance {
+	return s.instance
+}
+
+// Distance returns the total length of the routes.
+func (s *State) Distance() float64 {
+	return s.TotalCost()
+}
+
+// Start returns the earliest start of the service of the assigned customer.
+func (s *State) Start(customer int) float64 {
+	return s.schedules[s.RouteOf(customer)].start[s.Position(customer)+1]
+}
+
+// schedule recomputes the forward and backward schedules of the route r.
+func (s *State) schedule(r int) {
+	in := s.instance
+	customers := s.Route(r)
+	sch := &s.schedules[r]
+	n := len(customers)
+	node := func(i int) int {
+		if i == 0 || i == n+1 {
+			return Depot
+		}
+		return customers[i-1]
+	}
+	sch.start = slices.Grow(sch.start[:0], n+2)[:n+2]
+	sch.latest = slices.Grow(sch.latest[:0], n+2)[:n+2]
+
+	sch.start[0] = in.Ready[Depot]
+	for i := 1; i <= n+1; i++ {
+		prev, curr := node(i-1), node(i)
+		sch.start[i] = max(in.Ready[curr], sch.start[i-1]+in.Service[prev]+in.Dists[prev][curr])
+	}
+	sch.latest[n+1] = in.Due[Depot]
+	for i := n; i >= 0; i-- {
+		curr, next := node(i), node(i+1)
+		sch.latest[i] = min(in.Due[curr], sch.latest[i+1]-in.Service[curr]-in.Dists[curr][next])
+	}
+}
+
+// Remove unassigns the customer, the route is dropped if it becomes empty. Removing a customer
+// never violates the time windows (the travel times satisfy the triangle inequality).
+func (s *State) Remove(customer int) {
+	r, dropped := s.Routes.Remove(customer)
+	if !dropped {
+		s.schedule(r)
+		return
+	}
+	last := len(s.schedules) - 1
+	s.schedules[r] = s.schedules[last]
+	s.schedules = s.schedules[:last]
+}
+
+// CanInsert reports whether the customer can be inserted at the position pos of the route r
+// without violating the capacity and the time windows. It takes O(1), a new route (r equal to
+// NumRoutes) always fits.
+func (s *State) CanInsert(customer, r, pos int) bool {
+	if r == s.NumRoutes() {
+		return true
+	}
+	in := s.instance
+	sch := &s.schedules[r]
+	if s.Load(r)+in.Demands[customer] > in.Capacity {
+		return false
+	}
+	prev, next := s.Adjacent(r, pos)
+	start := max(in.Ready[customer], sch.start[pos]+in.Service[prev]+in.Dists[prev][customer])
+	if start > in.Due[customer] {
+		return false
+	}
+	return start+in.Service[customer]+in.Dists[customer][next] <= sch.latest[pos+1]
+}
+
+// Insert assigns the removed customer at the position pos of the route r, r equal to NumRoutes
+// opens a new route. The feasibility is not checked.
+func (s *State) Insert(customer, r, pos int) {
+	if r == s.NumRoutes() {
+		s.schedules = append(s.schedules, schedule{})
+	}
+	s.Routes.Insert(customer, r, pos)
+	s.schedule(r)
+}
@@ -0,0 +1,4 @@
+Route #1: 3 1 2 4
+Route #2: 7 6 5 8
+Route #3: 12 11 10 9
+Cost 262.89
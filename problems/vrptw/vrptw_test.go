@@ -0,0 +1,393 @@
+package vrptw
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/bibenga/alns"
+	"github.com/bibenga/alns/problems/cvrp"
+)
+
+// toyKnown is the distance of the known feasible solution testdata/toy12.sol with 3 vehicles.
+const toyKnown = 262.893
+
+func loadInstance(t testing.TB) *Instance {
+	instance, err := ReadFile("testdata/toy12.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return instance
+}
+
+func knownState(t testing.TB, instance *Instance) *State {
+	solution, err := cvrp.ReadSolutionFile("testdata/toy12.sol")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := NewStateFromSolution(instance, solution)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// checkState simulates the routes from scratch.
+func checkState(t *testing.T, s *State) {
+	t.Helper()
+	in := s.instance
+	visited := len(s.Unassigned())
+	total := 0.0
+	for r := range s.NumRoutes() {
+		customers := s.Route(r)
+		if len(customers) == 0 {
+			t.Fatalf("the route %d is empty", r)
+		}
+		load, cost, time, prev := 0, 0.0, in.Ready[Depot], Depot
+		for _, customer := range customers {
+			if s.RouteOf(customer) != r {
+				t.Fatalf("the customer %d is expected in the route %d, actual %d", customer, r, s.RouteOf(customer))
+			}
+			time = max(in.Ready[customer], time+in.Service[prev]+in.Dists[prev][customer])
+			if time > in.Due[customer] {
+				t.Fatalf("the route %d: the customer %d is served at %f after its due time %f", r, customer, time, in.Due[customer])
+			}
+			if time != s.Start(customer) {
+				t.Fatalf("the route %d: the cached start %f of the customer %d, actual %f", r, s.Start(customer), customer, time)
+			}
+			load += in.Demands[customer]
+			cost += in.Dists[prev][customer]
+			prev = customer
+		}
+		if time+in.Service[prev]+in.Dists[prev][Depot] > in.Due[Depot] {
+			t.Fatalf("the route %d returns to the depot too late", r)
+		}
+		cost += in.Dists[prev][Depot]
+		visited += len(customers)
+		total += cost
+		if load != s.Load(r) || load > in.Capacity {
+			t.Fatalf("the route %d: the cached load %d, actual %d, capacity %d", r, s.Load(r), load, in.Capacity)
+		}
+		if math.Abs(cost-s.Cost(r)) > 1e-6 {
+			t.Fatalf("the route %d: the cached cost %f, actual %f", r, s.Cost(r), cost)
+		}
+	}
+	if visited != in.Size()-1 {
+		t.Fatalf("%d customers expected, actual %d", in.Size()-1, visited)
+	}
+	if math.Abs(total-s.Distance()) > 1e-6 {
+		t.Fatalf("the cached distance %f differs from the total cost %f", s.Distance(), total)
+	}
+}
+
+func TestParse(t *testing.T) {
+	instance := loadInstance(t)
+	if instance.Name != "TOY12" || instance.Size() != 13 || instance.Vehicles != 5 || instance.Capacity != 60 {
+		t.Fatalf("unexpected instance %q, size %d, vehicles %d, capacity %d",
+			instance.Name, instance.Size(), instance.Vehicles, instance.Capacity)
+	}
+	if instance.Ready[3] != 6 || instance.Due[3] != 60 || instance.Service[3] != 10 || instance.Due[Depot] != 400 {
+		t.Fatalf("unexpected time windows %v %v", instance.Ready, instance.Due)
+	}
+
+	// the Gehring & Homberger files differ only in the spacing
+	gh := "C1_2_X\n\nVEHICLE\nNUMBER     CAPACITY\n  50          200\n\nCUSTOMER\n" +
+		"CUST NO.  XCOORD.    YCOORD.    DEMAND    READY TIME  DUE DATE   SERVICE TIME\n\n" +
+		"    0      70         70          0          0       1351          0\n" +
+		"    1      33         78         20        750        809         90\n" +
+		"    2      59         52         20        261        320         90\n"
+	instance, err := Parse(strings.NewReader(gh))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if instance.Name != "C1_2_X" || instance.Size() != 3 || instance.Vehicles != 50 || instance.Due[2] != 320 {
+		t.Fatalf("unexpected instance %+v", instance)
+	}
+
+	unreachable := strings.Replace(gh, "261        320", "  1          2", 1)
+	if _, err := Parse(strings.NewReader(unreachable)); err == nil {
+		t.Fatal("an error expected for a customer that cannot be served")
+	}
+}
+
+func TestKnownSolution(t *testing.T) {
+	instance := loadInstance(t)
+	s := knownState(t, instance)
+	checkState(t, s)
+	if !s.Feasible() || s.NumRoutes() != 3 || math.Abs(s.Distance()-toyKnown) > 1e-3 {
+		t.Fatalf("a feasible solution with 3 routes and distance %f expected, actual %d routes and %f",
+			toyKnown, s.NumRoutes(), s.Distance())
+	}
+
+	// the customer 3 is due at 60, it cannot be served at the end of the first route
+	infeasible := &cvrp.Solution{Routes: [][]int{{1, 2, 4, 3}, {7, 6, 5, 8}, {12, 11, 10, 9}}}
+	if _, err := NewStateFromSolution(instance, infeasible); err == nil {
+		t.Fatal("a time window error expected")
+	}
+	overloaded := &cvrp.Solution{Routes: [][]int{{3, 1, 2, 4, 5}, {7, 6, 8}, {12, 11, 10, 9}}}
+	if _, err := NewStateFromSolution(instance, overloaded); err == nil {
+		t.Fatal("a capacity error expected")
+	}
+}
+
+func TestInsertionFeasibility(t *testing.T) {
+	instance := loadInstance(t)
+	s := knownState(t, instance)
+	// CanInsert must agree with a simulation of every insertion
+	for _, customer := range []int{1, 5, 10, 12} {
+		destroyed := s.Clone()
+		destroyed.Remove(customer)
+		checkState(t, destroyed)
+		for r := range destroyed.NumRoutes() {
+			for pos := range len(destroyed.Route(r)) + 1 {
+				candidate := destroyed.Clone()
+				candidate.Insert(customer, r, pos)
+				expected := feasible(candidate, r)
+				if actual := destroyed.CanInsert(customer, r, pos); actual != expected {
+					t.Fatalf("the customer %d at %d/%d: feasibility %v expected, actual %v", customer, r, pos, expected, actual)
+				}
+			}
+		}
+	}
+}
+
+// feasible simulates the route r.
+func feasible(s *State, r int) bool {
+	in := s.instance
+	if s.Load(r) > in.Capacity {
+		return false
+	}
+	time, prev := in.Ready[Depot], Depot
+	for _, customer := range s.Route(r) {
+		time = max(in.Ready[customer], time+in.Service[prev]+in.Dists[prev][customer])
+		if time > in.Due[customer] {
+			return false
+		}
+		prev = customer
+	}
+	return time+in.Service[prev]+in.Dists[prev][Depot] <= in.Due[Depot]
+}
+
+func TestObjective(t *testing.T) {
+	instance := loadInstance(t)
+	s := knownState(t, instance)
+	more := s.Clone()
+	more.Remove(9)
+	more.Insert(9, more.NumRoutes(), 0)
+	if s.Compare(more) >= 0 || s.Objective() >= more.Objective() {
+		t.Fatal("the solution with fewer vehicles is expected to be better")
+	}
+	// the same number of vehicles, the distance decides
+	longer := s.Clone()
+	longer.Remove(11)
+	longer.Insert(11, longer.RouteOf(12), 0)
+	if longer.Distance() <= s.Distance() || s.Compare(longer) >= 0 || s.Objective() >= longer.Objective() {
+		t.Fatal("the shorter solution is expected to be better")
+	}
+	if objectives := s.Objectives(); objectives[0] != 3 || objectives[1] != s.Distance() {
+		t.Fatalf("the objectives [3 %f] expected, actual %v", s.Distance(), objectives)
+	}
+}
+
+func TestSlack(t *testing.T) {
+	instance := loadInstance(t)
+	rnd := rand.New(rand.NewPCG(1, 2))
+	states := []*State{knownState(t, instance)}
+	for range 10 {
+		destroyed, _ := RandomRemoval(0.3)(states[0], rnd)
+		states = append(states, destroyed.(*State))
+	}
+	// the service of a customer can start as late as the cached latest start (up to the rounding) and not later
+	for _, s := range states {
+		for r := range s.NumRoutes() {
+			sch := s.schedules[r]
+			for pos := 1; pos <= len(s.Route(r)); pos++ {
+				if sch.latest[pos] < sch.start[pos] {
+					t.Fatalf("the route %d: the negative slack at %d", r, pos)
+				}
+				if !delayed(s, r, pos, sch.latest[pos]-1e-9) || delayed(s, r, pos, sch.latest[pos]+1e-6) {
+					t.Fatalf("the route %d: the latest start %f at %d is not tight", r, sch.latest[pos], pos)
+				}
+			}
+		}
+	}
+}
+
+// delayed reports whether the route r is feasible if the service at the position pos starts at time.
+func delayed(s *State, r, pos int, time float64) bool {
+	in := s.instance
+	customers := s.Route(r)
+	prev := customers[pos-1]
+	if time > in.Due[prev] {
+		return false
+	}
+	for _, customer := range customers[pos:] {
+		time = max(in.Ready[customer], time+in.Service[prev]+in.Dists[prev][customer])
+		if time > in.Due[customer] {
+			return false
+		}
+		prev = customer
+	}
+	return time+in.Service[prev]+in.Dists[prev][Depot] <= in.Due[Depot]
+}
+
+func TestDestroy(t *testing.T) {
+	instance := loadInstance(t)
+	rnd := rand.New(rand.NewPCG(1, 2))
+	initial := knownState(t, instance)
+
+	t.Run("RouteRemoval", func(t *testing.T) {
+		destroyed, _ := RouteRemoval(initial, rnd)
+		removed := slices.Sorted(slices.Values(destroyed.(*State).Unassigned()))
+		route := slices.Sorted(slices.Values(initial.Route(initial.RouteOf(removed[0]))))
+		if destroyed.(*State).NumRoutes() != 2 || !slices.Equal(removed, route) {
+			t.Fatalf("the customers of a route %v expected, actual %v", route, removed)
+		}
+	})
+
+	t.Run("TimeRemoval", func(t *testing.T) {
+		// with a very large p the second customer has the closest start to the first one
+		destroyed, _ := TimeRemoval(0.2, 1e9)(initial, rnd)
+		removed := destroyed.(*State).Unassigned()
+		gap := math.Abs(initial.Start(removed[0]) - initial.Start(removed[1]))
+		for customer := 1; customer < instance.Size(); customer++ {
+			if customer != removed[0] && math.Abs(initial.Start(removed[0])-initial.Start(customer)) < gap {
+				t.Fatalf("the customer %d starts closer to %d than %d", customer, removed[0], removed[1])
+			}
+		}
+	})
+
+	for name, destroyOp := range map[string]alns.Operator{
+		"RandomRemoval": RandomRemoval(0.3),
+		"TimeRemoval":   TimeRemoval(0.3, 3),
+	} {
+		fingerprint := initial.Fingerprint()
+		destroyed, err := destroyOp(initial, rnd)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if initial.Fingerprint() != fingerprint {
+			t.Fatalf("%s: the input state was mutated", name)
+		}
+		checkState(t, destroyed.(*State))
+		if removed := len(destroyed.(*State).Unassigned()); removed != 3 {
+			t.Fatalf("%s: 3 removed customers (30%% of 12) expected, actual %d", name, removed)
+		}
+	}
+}
+
+func TestRepair(t *testing.T) {
+	instance := loadInstance(t)
+	rnd := rand.New(rand.NewPCG(1, 2))
+	initial := knownState(t, instance)
+	for name, repairOp := range map[string]alns.Operator{
+		"GreedyRepair": GreedyRepair,
+		"TimeRepair":   TimeRepair,
+		"RegretRepair": RegretRepair(3),
+	} {
+		t.Run(name, func(t *testing.T) {
+			// a single customer is inserted at its cheapest feasible position
+			for customer := 1; customer < instance.Size(); customer++ {
+				destroyed := initial.Clone()
+				destroyed.Remove(customer)
+				cheapest := math.Inf(1)
+				for r := range destroyed.NumRoutes() {
+					for pos := range len(destroyed.Route(r)) + 1 {
+						candidate := destroyed.Clone()
+						candidate.Insert(customer, r, pos)
+						if feasible(candidate, r) {
+							cheapest = min(cheapest, candidate.Distance())
+						}
+					}
+				}
+				repaired, err := repairOp(destroyed, rnd)
+				if err != nil {
+					t.Fatal(err)
+				}
+				checkState(t, repaired.(*State))
+				if !math.IsInf(cheapest, 1) && math.Abs(repaired.(*State).Distance()-cheapest) > 1e-6 {
+					t.Fatalf("the customer %d: the distance %f expected, actual %f", customer, cheapest, repaired.(*State).Distance())
+				}
+			}
+
+			for range 20 {
+				destroyed, _ := RandomRemoval(0.5)(initial, rnd)
+				repaired, err := repairOp(destroyed, rnd)
+				if err != nil {
+					t.Fatal(err)
+				}
+				checkState(t, repaired.(*State))
+				if !repaired.(*State).Feasible() {
+					t.Fatal("a feasible solution expected")
+				}
+			}
+		})
+	}
+}
+
+func TestSolve(t *testing.T) {
+	instance := loadInstance(t)
+	rnd := rand.New(rand.NewPCG(12, 34))
+	initial, err := GreedyRepair(NewState(instance), rnd)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a := alns.ALNS{
+		Rnd: rnd,
+		DestroyOperators: []alns.Operator{
+			RandomRemoval(0.3),
+			TimeRemoval(0.3, 3),
+			RouteRemoval,
+		},
+		RepairOperators: []alns.Operator{GreedyRepair, TimeRepair, RegretRepair(2)},
+		CheckOperators:  true,
+	}
+	selector, err := alns.NewRouletteWheel([4]float64{3, 2, 1, 0.5}, 0.8, 3, 3, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	accept := alns.HillClimbing{}
+	stop := alns.MaxIterations{MaxIterations: 500}
+	result, err := a.Iterate(initial, &selector, &accept, &stop)
+	if err != nil {
+		t.Fatal(err)
+	}
+	best := result.BestState.(*State)
+	checkState(t, best)
+	if !best.Feasible() || best.NumRoutes() > 3 || best.Distance() > toyKnown {
+		t.Fatalf("a solution not worse than the known one expected, actual %d routes and %f", best.NumRoutes(), best.Distance())
+	}
+}
+
+func TestRegretRepairUrgency(t *testing.T) {
+	// the customer 3 fits only into the route of the customer 1 and the customer 4 has a larger
+	// regret, but it also fits into the route of the customer 2
+	instance, err := Parse(strings.NewReader(`URGENCY
+VEHICLE
+NUMBER CAPACITY
+3 10
+CUSTOMER
+CUST NO. XCOORD. YCOORD. DEMAND READY TIME DUE DATE SERVICE TIME
+0 0 0 0 0 1000 0
+1 10 0 1 0 1000 0
+2 0 10 9 0 1000 0
+3 11 0 9 0 1000 0
+4 9 1 1 0 1000 0
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := NewState(instance)
+	s.Insert(1, 0, 0)
+	s.Insert(2, 1, 0)
+	repaired, err := RegretRepair(3)(s, rand.New(rand.NewPCG(1, 2)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := repaired.(*State); r.NumRoutes() != 2 || len(r.Unassigned()) != 0 {
+		t.Fatalf("2 routes expected, actual %v", r.Solution())
+	}
+}
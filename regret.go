@@ -0,0 +1,102 @@
+package alns
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+)
+
+// Insertion is a position where an unassigned element can be inserted and the change of
+// the objective caused by the insertion. The meaning of Position is defined by the state,
+// e.g. an index in a sequence or the node after which the element is inserted.
+type Insertion struct {
+	Position int
+	Cost     float64
+}
+
+// Insertable is implemented by the states that generic repair operators (e.g. RegretInsertion)
+// can work on. The elements are identified by integers.
+type Insertable interface {
+	State
+	// Unassigned returns the elements that must be inserted.
+	Unassigned() []int
+	// Insertions returns the feasible insertion positions of the unassigned element.
+	Insertions(element int) []Insertion
+	// Insert inserts the unassigned element at the position returned by Insertions.
+	Insert(element, position int)
+	// CloneInsertable returns a copy of the state, the repair operators must not modify their input.
+	CloneInsertable() Insertable
+}
+
+// RegretInsertion builds the regret-k insertion of Ropke & Pisinger (2006). In every step the
+// unassigned element with the largest regret, i.e. the sum of the differences between the cost of
+// its cheapest insertion and the costs of the next k-1 cheapest insertions, is inserted at its
+// cheapest position. A large k (e.g. math.MaxInt) means all positions; elements with fewer than
+// k positions use the positions they have. Ties are broken by the cheaper insertion.
+//
+// Every insertion cost is perturbed by a noise drawn uniformly from [-noise, noise] and every
+// position is skipped (blinks) with the probability blinkRate, see SISR (Christiaens & Vanden
+// Berghe, 2020). If all positions of an element blink, none of them is skipped. The random numbers
+// are drawn from the rnd passed to the operator.
+//
+// The operator inserts into a copy of the state (see Insertable.CloneInsertable) and returns an error
+// if the state does not implement Insertable or an element has no insertion position.
+func RegretInsertion(k int, noise, blinkRate float64) (Operator, error) {
+	if k < 2 {
+		return nil, newValidationError("k", "k must be at least 2, actual %d", k)
+	}
+	if noise < 0 {
+		return nil, newValidationError("noise", "noise must be non-negative, actual %f", noise)
+	}
+	if blinkRate < 0 || blinkRate >= 1 {
+		return nil, newValidationError("blinkRate", "blinkRate must be in [0, 1), actual %f", blinkRate)
+	}
+	return func(state State, rnd *rand.Rand) (State, error) {
+		insertable, ok := state.(Insertable)
+		if !ok {
+			return nil, fmt.Errorf("regret insertion: %T does not implement Insertable", state)
+		}
+		repaired := insertable.CloneInsertable()
+		var costs []Insertion
+		for len(repaired.Unassigned()) > 0 {
+			bestElement, bestPosition := 0, 0
+			bestRegret, bestCost := 0.0, 0.0
+			found := false
+			for _, element := range repaired.Unassigned() {
+				insertions := repaired.Insertions(element)
+				if len(insertions) == 0 {
+					return nil, fmt.Errorf("regret insertion: no insertion position for the element %d", element)
+				}
+				costs = costs[:0]
+				for _, insertion := range insertions {
+					if blinkRate > 0 && rnd.Float64() < blinkRate {
+						continue
+					}
+					costs = append(costs, insertion)
+				}
+				if len(costs) == 0 {
+					costs = append(costs, insertions...)
+				}
+				if noise > 0 {
+					for i := range costs {
+						costs[i].Cost += noise * (2*rnd.Float64() - 1)
+					}
+				}
+				slices.SortFunc(costs, func(a, b Insertion) int {
+					return cmp.Compare(a.Cost, b.Cost)
+				})
+				regret := 0.0
+				for _, other := range costs[1:min(k, len(costs))] {
+					regret += other.Cost - costs[0].Cost
+				}
+				if !found || regret > bestRegret || (regret == bestRegret && costs[0].Cost < bestCost) {
+					bestElement, bestPosition, bestRegret, bestCost = element, costs[0].Position, regret, costs[0].Cost
+					found = true
+				}
+			}
+			repaired.Insert(bestElement, bestPosition)
+		}
+		return repaired, nil
+	}, nil
+}
@@ -0,0 +1,239 @@
+package alns
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// SequenceState is an open path over the assigned nodes, the objective is its length.
+type SequenceState struct {
+	dists      [][]float64
+	sequence   []int
+	unassigned []int
+}
+
+func (s *SequenceState) Objective() float64 {
+	v := 0.0
+	for i := 1; i < len(s.sequence); i++ {
+		v += s.dists[s.sequence[i-1]][s.sequence[i]]
+	}
+	return v
+}
+
+func (s *SequenceState) Unassigned() []int {
+	return s.unassigned
+}
+
+func (s *SequenceState) Insertions(node int) []Insertion {
+	insertions := make([]Insertion, 0, len(s.sequence)+1)
+	for pos := range len(s.sequence) + 1 {
+		cost := 0.0
+		switch {
+		case len(s.sequence) == 0:
+		case pos == 0:
+			cost = s.dists[node][s.sequence[0]]
+		case pos == len(s.sequence):
+			cost = s.dists[s.sequence[pos-1]][node]
+		default:
+			prev, next := s.sequence[pos-1], s.sequence[pos]
+			cost = s.dists[prev][node] + s.dists[node][next] - s.dists[prev][next]
+		}
+		insertions = append(insertions, Insertion{Position: pos, Cost: cost})
+	}
+	return insertions
+}
+
+func (s *SequenceState) Insert(node, pos int) {
+	s.sequence = slices.Insert(s.sequence, pos, node)
+	s.unassigned = slices.DeleteFunc(s.unassigned, func(n int) bool { return n == node })
+}
+
+func (s *SequenceState) CloneInsertable() Insertable {
+	return &SequenceState{dists: s.dists, sequence: slices.Clone(s.sequence), unassigned: slices.Clone(s.unassigned)}
+}
+
+func newSequenceState(dists [][]float64, sequence []int) *SequenceState {
+	s := SequenceState{dists: dists, sequence: slices.Clone(sequence)}
+	for node := range dists {
+		if !slices.Contains(sequence, node) {
+			s.unassigned = append(s.unassigned, node)
+		}
+	}
+	return &s
+}
+
+// lineDistances returns the distances of n nodes on a line.
+func lineDistances(n int) [][]float64 {
+	dists := make([][]float64, n)
+	for i := range dists {
+		dists[i] = make([]float64, n)
+		for j := range dists[i] {
+			dists[i][j] = math.Abs(float64(i - j))
+		}
+	}
+	return dists
+}
+
+// planeDistances returns the distances of n points scattered over a 100x100 square.
+func planeDistances(n int) [][]float64 {
+	rnd := rand.New(rand.NewPCG(uint64(n), 0))
+	coords := make([][2]float64, n)
+	for i := range coords {
+		coords[i] = [2]float64{100 * rnd.Float64(), 100 * rnd.Float64()}
+	}
+	dists := make([][]float64, n)
+	for i, a := range coords {
+		dists[i] = make([]float64, n)
+		for j, b := range coords {
+			dists[i][j] = math.Hypot(a[0]-b[0], a[1]-b[1])
+		}
+	}
+	return dists
+}
+
+func TestRegretInsertionValidation(t *testing.T) {
+	for name, args := range map[string]struct {
+		k                int
+		noise, blinkRate float64
+	}{
+		"k":         {1, 0, 0},
+		"noise":     {2, -1, 0},
+		"blinkRate": {2, 0, 1},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := RegretInsertion(args.k, args.noise, args.blinkRate); err == nil {
+				t.Fatal("an error expected")
+			}
+		})
+	}
+}
+
+func TestRegretInsertion(t *testing.T) {
+	// the nodes 0..9 on a line, inserting into the sequence [0, 9] gives the optimal path
+	dists := lineDistances(10)
+	rnd := rand.New(rand.NewPCG(1, 2))
+	for _, k := range []int{2, 3, math.MaxInt} {
+		op, err := RegretInsertion(k, 0, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		repaired, err := op(newSequenceState(dists, []int{0, 9}), rnd)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if repaired.Objective() != 9 || len(repaired.(*SequenceState).Unassigned()) != 0 {
+			t.Fatalf("k=%d: the path of length 9 expected, actual %v", k, repaired.(*SequenceState).sequence)
+		}
+	}
+}
+
+// CostTableState has fixed insertion costs and records the order of the insertions.
+type CostTableState struct {
+	costs      map[int][]float64
+	unassigned []int
+	order      []int
+}
+
+func (s *CostTableState) Objective() float64 {
+	return 0
+}
+
+func (s *CostTableState) Unassigned() []int {
+	return s.unassigned
+}
+
+func (s *CostTableState) Insertions(element int) []Insertion {
+	insertions := make([]Insertion, len(s.costs[element]))
+	for pos, cost := range s.costs[element] {
+		insertions[pos] = Insertion{Position: pos, Cost: cost}
+	}
+	return insertions
+}
+
+func (s *CostTableState) Insert(element, pos int) {
+	s.order = append(s.order, element)
+	s.unassigned = slices.DeleteFunc(s.unassigned, func(e int) bool { return e == element })
+}
+
+func (s *CostTableState) CloneInsertable() Insertable {
+	return &CostTableState{costs: s.costs, unassigned: slices.Clone(s.unassigned), order: slices.Clone(s.order)}
+}
+
+func TestRegretInsertionOrder(t *testing.T) {
+	costs := map[int][]float64{
+		0: {1, 10, 10}, // regret-2 9, regret-3 18
+		1: {0, 1, 1},   // regret-2 1 and cheaper than 2, regret-3 2
+		2: {2, 3, 30},  // regret-2 1, regret-3 29
+	}
+	for k, expected := range map[int][]int{2: {0, 1, 2}, 3: {2, 0, 1}} {
+		op, err := RegretInsertion(k, 0, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		state := &CostTableState{costs: costs, unassigned: []int{0, 1, 2}}
+		repaired, err := op(state, rand.New(rand.NewPCG(1, 2)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if order := repaired.(*CostTableState).order; !slices.Equal(order, expected) {
+			t.Fatalf("k=%d: the insertion order %v expected, actual %v", k, expected, order)
+		}
+		if len(state.unassigned) != 3 || len(state.order) != 0 {
+			t.Fatalf("k=%d: the input state was modified", k)
+		}
+	}
+}
+
+func TestRegretInsertionNoiseAndBlinks(t *testing.T) {
+	dists := planeDistances(131)
+	rnd := rand.New(rand.NewPCG(1, 2))
+	exact, _ := RegretInsertion(2, 0, 0)
+	noisy, _ := RegretInsertion(2, 50, 0.2)
+
+	repaired, err := exact(newSequenceState(dists, nil), rnd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reference := repaired.(*SequenceState).sequence
+
+	// the same rnd state gives the same solution, different draws give different ones
+	first, _ := noisy(newSequenceState(dists, nil), rand.New(rand.NewPCG(3, 4)))
+	second, _ := noisy(newSequenceState(dists, nil), rand.New(rand.NewPCG(3, 4)))
+	third, _ := noisy(newSequenceState(dists, nil), rand.New(rand.NewPCG(5, 6)))
+	if !slices.Equal(first.(*SequenceState).sequence, second.(*SequenceState).sequence) {
+		t.Fatal("the noise is expected to be drawn from the operator rnd")
+	}
+	if slices.Equal(first.(*SequenceState).sequence, third.(*SequenceState).sequence) ||
+		slices.Equal(first.(*SequenceState).sequence, reference) {
+		t.Fatal("the noise and the blinks are expected to change the solution")
+	}
+	for _, s := range []State{first, third} {
+		if len(s.(*SequenceState).sequence) != len(dists) {
+			t.Fatal("all nodes are expected to be inserted")
+		}
+	}
+}
+
+func TestRegretInsertionErrors(t *testing.T) {
+	op, _ := RegretInsertion(2, 0, 0)
+	if _, err := op(&FakeState{}, rand.New(rand.NewPCG(1, 2))); err == nil {
+		t.Fatal("an error expected for a state that is not Insertable")
+	}
+	if _, err := op(&noPositionState{SequenceState: newSequenceState(lineDistances(2), nil)}, rand.New(rand.NewPCG(1, 2))); err == nil {
+		t.Fatal("an error expected for an element without positions")
+	}
+}
+
+type noPositionState struct {
+	*SequenceState
+}
+
+func (s *noPositionState) Insertions(node int) []Insertion {
+	return nil
+}
+
+func (s *noPositionState) CloneInsertable() Insertable {
+	return &noPositionState{SequenceState: s.SequenceState.CloneInsertable().(*SequenceState)}
+}
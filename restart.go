@@ -0,0 +1,86 @@
+package alns
+
+import (
+	"math/rand/v2"
+)
+
+// RestartStrategy decides whether the current solution should be reset.
+// It is consulted once per iteration after the candidate solution has been evaluated.
+type RestartStrategy interface {
+	Restart(rnd *rand.Rand, best, current State, outcome Outcome) (bool, error)
+}
+
+// RestartTarget defines which solution replaces the current solution on a restart.
+type RestartTarget int
+
+const (
+	RestartToBest  RestartTarget = iota // Restart from the best solution
+	RestartToElite                      // Restart from a random solution of the pool (ALNS.Pool)
+)
+
+// The `NoImprovementRestart` restarts after MaxIterations iterations without a new global best.
+type NoImprovementRestart struct {
+	MaxIterations int
+	counter       int
+}
+
+var _ RestartStrategy = &NoImprovementRestart{}
+
+func NewNoImprovementRestart(maxIterations int) NoImprovementRestart {
+	return NoImprovementRestart{
+		MaxIterations: maxIterations,
+	}
+}
+
+func (r *NoImprovementRestart) Restart(rnd *rand.Rand, best, current State, outcome Outcome) (bool, error) {
+	if outcome == Best {
+		r.counter = 0
+		return false, nil
+	}
+	r.counter++
+	if r.counter >= r.MaxIterations {
+		r.counter = 0
+		return true, nil
+	}
+	return false, nil
+}
+
+// The `PeriodicRestart` restarts every Period iterations.
+type PeriodicRestart struct {
+	Period  int
+	counter int
+}
+
+var _ RestartStrategy = &PeriodicRestart{}
+
+func NewPeriodicRestart(period int) PeriodicRestart {
+	return PeriodicRestart{
+		Period: period,
+	}
+}
+
+func (r *PeriodicRestart) Restart(rnd *rand.Rand, best, current State, outcome Outcome) (bool, error) {
+	r.counter++
+	if r.counter >= r.Period {
+		r.counter = 0
+		return true, nil
+	}
+	return false, nil
+}
+
+// The `RandomRestart` restarts with the given probability in each iteration.
+type RandomRestart struct {
+	Probability float64
+}
+
+var _ RestartStrategy = &RandomRestart{}
+
+func NewRandomRestart(probability float64) RandomRestart {
+	return RandomRestart{
+		Probability: probability,
+	}
+}
+
+func (r *RandomRestart) Restart(rnd *rand.Rand, best, current State, outcome Outcome) (bool, error) {
+	return rnd.Float64() < r.Probability, nil
+}
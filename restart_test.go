@@ -0,0 +1,118 @@
+package alns
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+)
+
+type AcceptAll struct{}
+
+func (a *AcceptAll) Accept(rnd *rand.Rand, best, current, candidate State) (bool, error) {
+	return true, nil
+}
+
+func TestNoImprovementRestart(t *testing.T) {
+	restart := NoImprovementRestart{MaxIterations: 3}
+	outcomes := []Outcome{Accept, Reject, Best, Accept, Reject, Accept, Better, Reject}
+	expected := []bool{false, false, false, false, false, true, false, false}
+	for i, outcome := range outcomes {
+		got, err := restart.Restart(nil, nil, nil, outcome)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != expected[i] {
+			t.Errorf("iteration %d: restart %v expected, actual %v", i, expected[i], got)
+		}
+	}
+}
+
+func TestPeriodicRestart(t *testing.T) {
+	restart := PeriodicRestart{Period: 4}
+	count := 0
+	for range 20 {
+		if got, err := restart.Restart(nil, nil, nil, Accept); err != nil {
+			t.Fatal(err)
+		} else if got {
+			count++
+		}
+	}
+	if count != 5 {
+		t.Fatalf("5 restarts expected, actual %d", count)
+	}
+}
+
+func TestRandomRestart(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 3))
+	restart := RandomRestart{Probability: 0.1}
+	const total = 100000
+	count := 0
+	for range total {
+		if got, err := restart.Restart(r, nil, nil, Accept); err != nil {
+			t.Fatal(err)
+		} else if got {
+			count++
+		}
+	}
+	expected := 0.1 * total
+	if got := float64(count); math.Abs(got-expected)/expected > 0.05 {
+		t.Fatalf("got %f restarts, expected ~%f", got, expected)
+	}
+}
+
+func TestAlnsRestart(t *testing.T) {
+	solve := func(target RestartTarget) *Result {
+		opSelect, _ := NewRouletteWheel([4]float64{3, 2, 1, 0.5}, 0.8, 1, 1, nil)
+		accept := AcceptAll{}
+		stop := MaxIterations{MaxIterations: 100}
+		restart := PeriodicRestart{Period: 10}
+		pool := NewSolutionPool(3, 0)
+		a := ALNS{
+			Rnd: rand.New(rand.NewPCG(1, 2)),
+			DestroyOperators: []Operator{
+				func(state State, rnd *rand.Rand) (State, error) { return state.(*FakeState).Clone(), nil },
+			},
+			RepairOperators: []Operator{
+				func(state State, rnd *rand.Rand) (State, error) {
+					state.(*FakeState).objective = rnd.Float64()
+					return state, nil
+				},
+			},
+			Pool:          &pool,
+			Restart:       &restart,
+			RestartTarget: target,
+		}
+		res, err := a.Iterate(&FakeState{objective: 1}, &opSelect, &accept, &stop)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return res
+	}
+
+	t.Run("Best", func(t *testing.T) {
+		res := solve(RestartToBest)
+		if len(res.Statistics.Restarts) != 10 {
+			t.Fatalf("10 restarts expected, actual %d", len(res.Statistics.Restarts))
+		}
+		for i, event := range res.Statistics.Restarts {
+			if event.Iteration != (i+1)*10 {
+				t.Errorf("restart %d: iteration %d expected, actual %d", i, (i+1)*10, event.Iteration)
+			}
+			if event.To > event.From {
+				t.Errorf("restart %d: the best solution %f is worse than the current %f", i, event.To, event.From)
+			}
+		}
+	})
+
+	t.Run("Elite", func(t *testing.T) {
+		res := solve(RestartToElite)
+		if len(res.Statistics.Restarts) != 10 {
+			t.Fatalf("10 restarts expected, actual %d", len(res.Statistics.Restarts))
+		}
+		worst := res.Pool[len(res.Pool)-1].Objective()
+		last := res.Statistics.Restarts[len(res.Statistics.Restarts)-1]
+		if last.To > worst {
+			t.Fatalf("the restart solution %f is not an elite one (worst %f)", last.To, worst)
+		}
+	})
+}
@@ -1,6 +1,10 @@
 package alns
 
 type Result struct {
-	BestState  State
-	Statistics Statistics
+	BestState         State // the best feasible solution or the initial solution if no feasible solution was found
+	BestFeasibleState State // the best feasible solution or nil if no feasible solution was found
+	Statistics        Statistics
+	Pool              []State // the elite solutions, the best first; only if ALNS.Pool is set
+	ParetoFront       []State // the non-dominated solutions; only if ALNS.Pareto is set
+	Seed              *uint64 // the master seed of the random streams; only if ALNS.Seeds is set
 }
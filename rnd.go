@@ -1,6 +1,11 @@
 package alns
 
-import "math/rand/v2"
+import (
+	"encoding/hex"
+	"hash/fnv"
+	"math/rand/v2"
+	"strconv"
+)
 
 type randomSource struct{}
 
@@ -11,3 +16,116 @@ func (r *randomSource) Uint64() uint64 {
 var _ rand.Source = &randomSource{}
 
 var RuntimeRand *rand.Rand = rand.New(&randomSource{})
+
+// The kinds of the random streams of a run, see SeedSequence.
+const (
+	SelectStream      = "select"       // the operator selection scheme
+	AcceptStream      = "accept"       // the acceptance criterion
+	StopStream        = "stop"         // the stopping criterion
+	DestroyStream     = "destroy"      // a destroy operator, indexed by the operator
+	RepairStream      = "repair"       // a repair operator, indexed by the operator
+	LocalSearchStream = "local-search" // a local search, indexed by the local search
+	EngineStream      = "engine"       // the remaining decisions: restarts, local search probability, degree of destruction
+)
+
+// SeedSequence derives independent random streams for the components of a run from a master seed.
+// Every component draws from its own PCG stream, so an additional random call in one operator does not
+// shift the decisions of the other components and a run can be replayed exactly from the master seed.
+type SeedSequence struct {
+	Master uint64
+}
+
+func NewSeedSequence(master uint64) SeedSequence {
+	return SeedSequence{Master: master}
+}
+
+// Rand returns a new generator of the stream of the index-th component of the kind (e.g. DestroyStream, 2).
+// The same master seed, kind and index always give the same stream.
+func (s SeedSequence) Rand(kind string, index int) *rand.Rand {
+	return rand.New(s.source(kind, index))
+}
+
+func (s SeedSequence) source(kind string, index int) *rand.PCG {
+	h := fnv.New64a()
+	h.Write([]byte(kind))
+	seed1 := splitMix64(s.Master ^ splitMix64(h.Sum64()))
+	seed2 := splitMix64(seed1 ^ splitMix64(uint64(index)))
+	return rand.NewPCG(seed1, seed2)
+}
+
+// splitMix64 is the finalizer of SplitMix64, it scatters nearby seeds over the whole range.
+func splitMix64(x uint64) uint64 {
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
+
+// randomStreams are the random generators used by a run, all of them are ALNS.Rnd if ALNS.Seeds is not set.
+type randomStreams struct {
+	selection   *rand.Rand
+	acceptance  *rand.Rand
+	stopping    *rand.Rand
+	engine      *rand.Rand
+	destroy     []*rand.Rand
+	repair      []*rand.Rand
+	localSearch []*rand.Rand
+	sources     map[string]*rand.PCG // the sources by the stream name (e.g. "destroy/2"); only with SeedSequence
+}
+
+func newRandomStreams(a *ALNS) *randomStreams {
+	streams := randomStreams{
+		destroy:     make([]*rand.Rand, len(a.DestroyOperators)),
+		repair:      make([]*rand.Rand, len(a.RepairOperators)),
+		localSearch: make([]*rand.Rand, len(a.LocalSearches)),
+	}
+	if a.Seeds != nil {
+		streams.sources = make(map[string]*rand.PCG)
+	}
+	stream := func(kind string, index int) *rand.Rand {
+		if a.Seeds == nil {
+			return a.Rnd
+		}
+		source := a.Seeds.source(kind, index)
+		streams.sources[streamName(kind, index)] = source
+		return rand.New(source)
+	}
+	streams.selection = stream(SelectStream, 0)
+	streams.acceptance = stream(AcceptStream, 0)
+	streams.stopping = stream(StopStream, 0)
+	streams.engine = stream(EngineStream, 0)
+	for i := range streams.destroy {
+		streams.destroy[i] = stream(DestroyStream, i)
+	}
+	for i := range streams.repair {
+		streams.repair[i] = stream(RepairStream, i)
+	}
+	for i := range streams.localSearch {
+		streams.localSearch[i] = stream(LocalSearchStream, i)
+	}
+	return &streams
+}
+
+func streamName(kind string, index int) string {
+	switch kind {
+	case DestroyStream, RepairStream, LocalSearchStream:
+		return kind + "/" + strconv.Itoa(index)
+	default:
+		return kind
+	}
+}
+
+// states returns the hex encoded states of the named streams, nil without SeedSequence.
+func (s *randomStreams) states(names ...string) map[string]string {
+	if s.sources == nil {
+		return nil
+	}
+	states := make(map[string]string, len(names))
+	for _, name := range names {
+		if source, ok := s.sources[name]; ok {
+			state, _ := source.MarshalBinary() // never fails
+			states[name] = hex.EncodeToString(state)
+		}
+	}
+	return states
+}
@@ -0,0 +1,81 @@
+package alns
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestSeedSequence(t *testing.T) {
+	draw := func(rnd *rand.Rand) []uint64 {
+		values := make([]uint64, 5)
+		for i := range values {
+			values[i] = rnd.Uint64()
+		}
+		return values
+	}
+	seeds := NewSeedSequence(42)
+	reference := draw(seeds.Rand(DestroyStream, 0))
+	if !slices.Equal(reference, draw(NewSeedSequence(42).Rand(DestroyStream, 0))) {
+		t.Fatal("the same seed, kind and index are expected to give the same stream")
+	}
+	for _, other := range []*rand.Rand{
+		seeds.Rand(DestroyStream, 1),
+		seeds.Rand(RepairStream, 0),
+		NewSeedSequence(43).Rand(DestroyStream, 0),
+	} {
+		if slices.Equal(reference, draw(other)) {
+			t.Fatal("different components are expected to give different streams")
+		}
+	}
+}
+
+func TestAlnsSeeds(t *testing.T) {
+	solve := func(extraDraw bool) (*Result, []float64) {
+		var repaired []float64
+		seeds := NewSeedSequence(7)
+		a := ALNS{
+			Seeds: &seeds,
+			DestroyOperators: []Operator{
+				func(state State, rnd *rand.Rand) (State, error) {
+					if extraDraw {
+						rnd.Float64()
+					}
+					return state.(*FakeState).Clone(), nil
+				},
+				func(state State, rnd *rand.Rand) (State, error) { return state.(*FakeState).Clone(), nil },
+			},
+			RepairOperators: []Operator{
+				func(state State, rnd *rand.Rand) (State, error) {
+					state.(*FakeState).objective = rnd.Float64()
+					repaired = append(repaired, state.Objective())
+					return state, nil
+				},
+			},
+		}
+		selector, err := NewRouletteWheel([4]float64{1, 1, 1, 1}, 0.8, 2, 1, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		result, err := a.Iterate(&FakeState{objective: 1}, &selector, &HillClimbing{}, &MaxIterations{MaxIterations: 100})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return result, repaired
+	}
+
+	first, firstRepaired := solve(false)
+	second, secondRepaired := solve(false)
+	if first.Seed == nil || *first.Seed != 7 {
+		t.Fatalf("the seed 7 expected in the result, actual %v", first.Seed)
+	}
+	if first.BestState.Objective() != second.BestState.Objective() || !slices.Equal(firstRepaired, secondRepaired) {
+		t.Fatal("the runs with the same seed are expected to be the same")
+	}
+
+	// an additional draw in a destroy operator does not shift the other components
+	_, shiftedRepaired := solve(true)
+	if !slices.Equal(firstRepaired, shiftedRepaired) {
+		t.Fatal("the repair operator is expected to draw from its own stream")
+	}
+}
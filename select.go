@@ -3,6 +3,7 @@ package alns
 import (
 	"fmt"
 	"math/rand/v2"
+	"slices"
 )
 
 type OperatorSelectionScheme interface {
@@ -10,6 +11,13 @@ type OperatorSelectionScheme interface {
 	Update(candidate State, deleteOpIndx, repairOpIndx int, outcome Outcome) error
 }
 
+// WeightedScheme is an operator selection scheme that keeps a weight of every operator, e.g. RouletteWheel.
+type WeightedScheme interface {
+	OperatorSelectionScheme
+	// Weights returns copies of the weights of the destroy and the repair operators.
+	Weights() (destroy, repair []float64)
+}
+
 // The `RouletteWheel` scheme updates operator weights as a convex combination of the current weight, and the new score.
 type RouletteWheel struct {
 	scores          [4]float64 // representing the weight updates when the candidate solution results in a new global
@@ -23,7 +31,7 @@ type RouletteWheel struct {
 	coupledRWeights []float64  // used in Select for caching
 }
 
-var _ OperatorSelectionScheme = &RouletteWheel{}
+var _ WeightedScheme = &RouletteWheel{}
 
 func NewRouletteWheel(
 	scores [4]float64,
@@ -59,28 +67,28 @@ func NewRouletteWheel(
 
 func (s *RouletteWheel) validate() error {
 	if min(s.scores[0], s.scores[1], s.scores[2], s.scores[3]) < 0 {
-		return fmt.Errorf("negative scores are not understood")
+		return newValidationError("scores", "negative scores are not understood")
 	}
 
 	if !(0 <= s.decay && s.decay <= 1) {
-		return fmt.Errorf("decay outside [0, 1] not understood")
+		return newValidationError("decay", "decay outside [0, 1] not understood")
 	}
 
 	if s.opCoupling != nil {
 		if len(s.opCoupling) == 0 {
-			return fmt.Errorf("coupling matrix of shape (%d, %d), expected (%d, %d)",
+			return newValidationError("coupling", "coupling matrix of shape (%d, %d), expected (%d, %d)",
 				0, 0, s.numDestroy, s.numRepair)
 		}
 		rows := len(s.opCoupling)
 		cols := len(s.opCoupling[0])
 		for i, row := range s.opCoupling {
 			if len(row) != cols {
-				return fmt.Errorf("the number of columns in a row %d does not match the expected %d",
-					i, cols)
+				return newValidationError(fmt.Sprintf("coupling[%d]", i),
+					"the number of columns in a row %d does not match the expected %d", i, cols)
 			}
 		}
 		if rows != s.numDestroy || cols != s.numRepair {
-			return fmt.Errorf("coupling matrix of shape (%d, %d), expected (%d, %d)",
+			return newValidationError("coupling", "coupling matrix of shape (%d, %d), expected (%d, %d)",
 				rows, cols, s.numDestroy, s.numRepair)
 		}
 
@@ -93,7 +101,8 @@ func (s *RouletteWheel) validate() error {
 				}
 			}
 			if !isCoupled {
-				return fmt.Errorf("destroy operator %d has no coupled repair operators", i)
+				return newValidationError(fmt.Sprintf("coupling[%d]", i),
+					"destroy operator %d has no coupled repair operators", i)
 			}
 		}
 	}
@@ -136,3 +145,7 @@ func (s *RouletteWheel) Update(candidate State, deleteOpIndx int, repairOpIndx i
 
 	return nil
 }
+
+func (s *RouletteWheel) Weights() ([]float64, []float64) {
+	return slices.Clone(s.dWeights), slices.Clone(s.rWeights)
+}
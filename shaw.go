@@ -0,0 +1,108 @@
+package alns
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+)
+
+// ElementRemover is implemented by the states that generic destroy operators (e.g. ShawRemoval)
+// can work on. The elements are identified by integers, e.g. nodes, customers or jobs.
+type ElementRemover interface {
+	State
+	// Assigned returns the elements that can be removed.
+	Assigned() []int
+	// Remove removes the assigned element.
+	Remove(element int)
+	// CloneRemover returns a copy of the state, the destroy operators must not modify their input.
+	CloneRemover() ElementRemover
+}
+
+// Relatedness measures how related two elements are, lower values mean more related elements
+// (e.g. the distance between two nodes).
+type Relatedness func(a, b int) float64
+
+// RemovalSize is the number of elements removed by a destroy operator: Count elements or, if Count
+// is zero, the Fraction of the assigned elements (in (0, 1]). At least one element is removed.
+type RemovalSize struct {
+	Count    int
+	Fraction float64
+}
+
+// RemoveCount removes the count of elements.
+func RemoveCount(count int) RemovalSize {
+	return RemovalSize{Count: count}
+}
+
+// RemoveFraction removes the fraction of the assigned elements.
+func RemoveFraction(fraction float64) RemovalSize {
+	return RemovalSize{Fraction: fraction}
+}
+
+func (s RemovalSize) validate() error {
+	if s.Count < 0 {
+		return newValidationError("size", "count must be non-negative, actual %d", s.Count)
+	}
+	if s.Count == 0 && (s.Fraction <= 0 || s.Fraction > 1) {
+		return newValidationError("size", "fraction must be in (0, 1], actual %f", s.Fraction)
+	}
+	return nil
+}
+
+// of returns the number of elements to remove from n assigned elements.
+func (s RemovalSize) of(n int) int {
+	count := s.Count
+	if count <= 0 {
+		count = int(float64(n) * s.Fraction)
+	}
+	return min(max(count, 1), n)
+}
+
+// ShawRemoval builds the relatedness based removal of Shaw (1998) as described by Ropke & Pisinger
+// (2006). The first element is chosen at random, then an already removed element is chosen and
+// the assigned elements are sorted by the relatedness to it; the element at the index
+// y^p * len (y is uniform in [0, 1)) is removed. The determinism p >= 1 controls the randomness,
+// p = 1 is a random removal and the larger p the more the most related elements are preferred.
+//
+// The operator returns an error if the state does not implement ElementRemover.
+func ShawRemoval(relatedness Relatedness, size RemovalSize, p float64) (Operator, error) {
+	if relatedness == nil {
+		return nil, newValidationError("relatedness", "relatedness must be set")
+	}
+	if err := size.validate(); err != nil {
+		return nil, err
+	}
+	if p < 1 {
+		return nil, newValidationError("p", "p must be at least 1, actual %f", p)
+	}
+	return func(state State, rnd *rand.Rand) (State, error) {
+		remover, ok := state.(ElementRemover)
+		if !ok {
+			return nil, fmt.Errorf("shaw removal: %T does not implement ElementRemover", state)
+		}
+		destroyed := remover.CloneRemover()
+		candidates := slices.Clone(destroyed.Assigned())
+		if len(candidates) == 0 {
+			return destroyed, nil
+		}
+		toRemove := size.of(len(candidates))
+
+		i := rnd.IntN(len(candidates))
+		removed := []int{candidates[i]}
+		candidates = slices.Delete(candidates, i, i+1)
+		destroyed.Remove(removed[0])
+		for len(removed) < toRemove {
+			r := removed[rnd.IntN(len(removed))]
+			slices.SortFunc(candidates, func(a, b int) int {
+				return cmp.Compare(relatedness(r, a), relatedness(r, b))
+			})
+			i := int(math.Pow(rnd.Float64(), p) * float64(len(candidates)))
+			removed = append(removed, candidates[i])
+			candidates = slices.Delete(candidates, i, i+1)
+			destroyed.Remove(removed[len(removed)-1])
+		}
+		return destroyed, nil
+	}, nil
+}
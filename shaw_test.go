@@ -0,0 +1,171 @@
+package alns
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// NodeSetState is a set of assigned nodes, the objective is the number of removed nodes.
+type NodeSetState struct {
+	assigned []int
+	size     int
+}
+
+func (s *NodeSetState) Objective() float64 {
+	return float64(s.size - len(s.assigned))
+}
+
+func (s *NodeSetState) Assigned() []int {
+	return s.assigned
+}
+
+func (s *NodeSetState) Remove(node int) {
+	s.assigned = slices.DeleteFunc(s.assigned, func(n int) bool { return n == node })
+}
+
+func (s *NodeSetState) CloneRemover() ElementRemover {
+	return &NodeSetState{assigned: slices.Clone(s.assigned), size: s.size}
+}
+
+func newNodeSetState(n int) *NodeSetState {
+	assigned := make([]int, n)
+	for i := range assigned {
+		assigned[i] = i
+	}
+	return &NodeSetState{assigned: assigned, size: n}
+}
+
+func removedNodes(state *NodeSetState) []int {
+	var removed []int
+	for node := range state.size {
+		if !slices.Contains(state.assigned, node) {
+			removed = append(removed, node)
+		}
+	}
+	return removed
+}
+
+func TestShawRemovalValidation(t *testing.T) {
+	relatedness := func(a, b int) float64 { return 0 }
+	for name, args := range map[string]struct {
+		relatedness Relatedness
+		size        RemovalSize
+		p           float64
+	}{
+		"relatedness": {nil, RemoveCount(1), 1},
+		"count":       {relatedness, RemoveCount(-1), 1},
+		"fraction":    {relatedness, RemoveFraction(1.5), 1},
+		"empty":       {relatedness, RemovalSize{}, 1},
+		"p":           {relatedness, RemoveCount(1), 0.5},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ShawRemoval(args.relatedness, args.size, args.p); err == nil {
+				t.Fatal("an error expected")
+			}
+		})
+	}
+}
+
+func TestShawRemoval(t *testing.T) {
+	dists := planeDistances(131)
+	relatedness := func(a, b int) float64 {
+		return dists[a][b]
+	}
+	rnd := rand.New(rand.NewPCG(1, 2))
+	initial := newNodeSetState(len(dists))
+
+	for name, tc := range map[string]struct {
+		size     RemovalSize
+		expected int
+	}{
+		"count":    {RemoveCount(7), 7},
+		"fraction": {RemoveFraction(0.1), 13},
+		"minimum":  {RemoveFraction(0.001), 1},
+		"maximum":  {RemoveCount(1000), 131},
+	} {
+		t.Run(name, func(t *testing.T) {
+			op, err := ShawRemoval(relatedness, tc.size, 6)
+			if err != nil {
+				t.Fatal(err)
+			}
+			destroyed, err := op(initial, rnd)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(initial.Assigned()) != len(dists) {
+				t.Fatal("the input state was mutated")
+			}
+			if removed := removedNodes(destroyed.(*NodeSetState)); len(removed) != tc.expected {
+				t.Fatalf("%d removed nodes expected, actual %d", tc.expected, len(removed))
+			}
+		})
+	}
+}
+
+func TestShawRemovalDeterminism(t *testing.T) {
+	dists := planeDistances(131)
+	relatedness := func(a, b int) float64 {
+		return dists[a][b]
+	}
+	rnd := rand.New(rand.NewPCG(1, 2))
+
+	// with a very large p the second node is always the nearest neighbour of the first one
+	op, err := ShawRemoval(relatedness, RemoveCount(2), 1e9)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for range 20 {
+		destroyed, err := op(newNodeSetState(len(dists)), rnd)
+		if err != nil {
+			t.Fatal(err)
+		}
+		removed := removedNodes(destroyed.(*NodeSetState))
+		a, b := removed[0], removed[1]
+		nearest := func(from, to int) bool {
+			for node := range dists {
+				if node != from && dists[from][node] < dists[from][to] {
+					return false
+				}
+			}
+			return true
+		}
+		// the order of the removal is unknown
+		if !nearest(a, b) && !nearest(b, a) {
+			t.Fatalf("the nodes %d and %d are not nearest neighbours", a, b)
+		}
+	}
+
+	// the removed nodes of a deterministic removal are closer to each other than random ones
+	spread := func(p float64) float64 {
+		op, err := ShawRemoval(relatedness, RemoveCount(10), p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		total := 0.0
+		for range 50 {
+			destroyed, _ := op(newNodeSetState(len(dists)), rnd)
+			removed := removedNodes(destroyed.(*NodeSetState))
+			for _, a := range removed {
+				for _, b := range removed {
+					total += dists[a][b]
+				}
+			}
+		}
+		return total
+	}
+	if random, related := spread(1), spread(10); related >= random/2 {
+		t.Fatalf("related nodes expected to be close, the spread %f of p=10 vs %f of p=1", related, random)
+	}
+}
+
+func TestShawRemovalUnsupportedState(t *testing.T) {
+	op, err := ShawRemoval(func(a, b int) float64 { return 0 }, RemoveCount(1), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = op(&FakeState{}, rand.New(rand.NewPCG(1, 2)))
+	if err == nil {
+		t.Fatal("an error expected")
+	}
+}
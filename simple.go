@@ -25,8 +25,10 @@ func Iterate(
 		MaxIterations: maxIterations,
 	}
 
+	// a random master seed, the run can be replayed with the seed recorded in the result
+	seeds := NewSeedSequence(RuntimeRand.Uint64())
 	a := ALNS{
-		Rnd:               RuntimeRand,
+		Seeds:             &seeds,
 		CollectObjectives: false,
 		DestroyOperators:  destroyOperators,
 		RepairOperators:   repairOperators,
@@ -0,0 +1,90 @@
+package alns
+
+import (
+	"context"
+	"log/slog"
+)
+
+// The `SlogObserver` is a RunObserver that logs the progress of a run as structured records.
+// The start and the stop of the run are logged at RunLevel (a stop caused by an error at slog.LevelError),
+// every Interval-th iteration and every new best solution are logged at Level.
+type SlogObserver struct {
+	Logger   *slog.Logger
+	Interval int        // log every Interval-th iteration, zero logs only the new best solutions
+	Level    slog.Level // the level of the iteration records
+	RunLevel slog.Level // the level of the start and stop records
+}
+
+var _ RunObserver = &SlogObserver{}
+
+func NewSlogObserver(logger *slog.Logger, interval int, level slog.Level) *SlogObserver {
+	return &SlogObserver{
+		Logger:   logger,
+		Interval: interval,
+		Level:    level,
+		RunLevel: slog.LevelInfo,
+	}
+}
+
+func (o *SlogObserver) OnStart(event *StartEvent) error {
+	attrs := []slog.Attr{
+		slog.Float64("initial", event.Initial.Objective()),
+		slog.Any("destroy_operators", event.DestroyOperators),
+		slog.Any("repair_operators", event.RepairOperators),
+	}
+	if event.Seed != nil {
+		attrs = append(attrs, slog.Uint64("seed", *event.Seed))
+	}
+	o.Logger.LogAttrs(context.Background(), o.RunLevel, "alns start", attrs...)
+	return nil
+}
+
+func (o *SlogObserver) OnIteration(event *IterationEvent) error {
+	logged := event.Outcome == Best || (o.Interval > 0 && event.Iteration%o.Interval == 0)
+	if !logged || !o.Logger.Enabled(context.Background(), o.Level) {
+		return nil
+	}
+	attrs := []slog.Attr{
+		slog.Int("iteration", event.Iteration),
+		slog.Float64("best", event.Best.Objective()),
+		slog.Float64("current", event.Current.Objective()),
+		slog.String("outcome", event.Outcome.String()),
+		slog.String("destroy", event.DestroyName()),
+		slog.String("repair", event.RepairName()),
+		slog.Duration("elapsed", event.Runtime),
+	}
+	if destroy, repair := event.Weights(); destroy != nil {
+		attrs = append(attrs, slog.Group("weights",
+			slog.Group("destroy", o.weights(destroy, event.alns.destroyOperatorName)...),
+			slog.Group("repair", o.weights(repair, event.alns.repairOperatorName)...),
+		))
+	}
+	o.Logger.LogAttrs(context.Background(), o.Level, "alns iteration", attrs...)
+	return nil
+}
+
+// weights returns the weights by the names of the operators.
+func (o *SlogObserver) weights(weights []float64, name func(int) string) []any {
+	attrs := make([]any, len(weights))
+	for i, w := range weights {
+		attrs[i] = slog.Float64(name(i), w)
+	}
+	return attrs
+}
+
+func (o *SlogObserver) OnStop(event *StopEvent) {
+	level := o.RunLevel
+	attrs := []slog.Attr{
+		slog.String("reason", event.Reason),
+		slog.Int("iterations", event.Iterations),
+		slog.Duration("elapsed", event.Runtime),
+	}
+	if event.Best != nil {
+		attrs = append(attrs, slog.Float64("best", event.Best.Objective()))
+	}
+	if event.Err != nil {
+		level = slog.LevelError
+		attrs = append(attrs, slog.String("error", event.Err.Error()))
+	}
+	o.Logger.LogAttrs(context.Background(), level, "alns stop", attrs...)
+}
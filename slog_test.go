@@ -0,0 +1,99 @@
+package alns
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"math/rand/v2"
+	"testing"
+	"time"
+)
+
+func readLogRecords(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	var records []map[string]any
+	decoder := json.NewDecoder(buf)
+	for decoder.More() {
+		var record map[string]any
+		if err := decoder.Decode(&record); err != nil {
+			t.Fatal(err)
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestSlogObserver(t *testing.T) {
+	a, factory := traceFixture()
+	a.DestroyOperatorNames = []string{"first", "second"}
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	a.Observers = []Observer{NewSlogObserver(logger, 10, slog.LevelDebug)}
+	seeds := NewSeedSequence(3)
+	a.Seeds = &seeds
+	setup, _ := factory(3)
+	criterions := NewStoppingCriterions(&MaxRuntime{MaxRuntime: time.Hour}, setup.Stop)
+	result, err := a.Iterate(setup.InitialSolution, setup.Selector, setup.Acceptor, criterions)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	records := readLogRecords(t, &buf)
+	start, stop := records[0], records[len(records)-1]
+	if start["msg"] != "alns start" || start["level"] != "INFO" || start["seed"] != 3.0 {
+		t.Fatalf("the start record expected, actual %v", start)
+	}
+	if stop["msg"] != "alns stop" || stop["reason"] != "MaxIterations" || stop["iterations"] != 50.0 ||
+		stop["best"] != result.BestState.Objective() {
+		t.Fatalf("the stop record expected, actual %v", stop)
+	}
+
+	intervals := 0
+	for _, record := range records[1 : len(records)-1] {
+		if record["msg"] != "alns iteration" || record["level"] != "DEBUG" {
+			t.Fatalf("an iteration record expected, actual %v", record)
+		}
+		iteration := int(record["iteration"].(float64))
+		if iteration%10 == 0 {
+			intervals++
+		} else if record["outcome"] != "Best" {
+			t.Fatalf("only every 10th iteration and new best solutions expected, actual %v", record)
+		}
+		if name := record["destroy"]; name != "first" && name != "second" {
+			t.Fatalf("the name of the destroy operator expected, actual %v", name)
+		}
+		weights := record["weights"].(map[string]any)
+		if _, ok := weights["destroy"].(map[string]any)["second"]; !ok {
+			t.Fatalf("the weights by the operator names expected, actual %v", weights)
+		}
+		if _, ok := weights["repair"].(map[string]any)["repair operator 0"]; !ok {
+			t.Fatalf("the default names of the operators expected, actual %v", weights)
+		}
+	}
+	if intervals != 5 {
+		t.Fatalf("5 interval records expected, actual %d", intervals)
+	}
+}
+
+func TestSlogObserverError(t *testing.T) {
+	a, factory := traceFixture()
+	a.RepairOperators = []Operator{
+		func(state State, rnd *rand.Rand) (State, error) { return nil, errors.New("broken") },
+	}
+	a.Rnd = rand.New(rand.NewPCG(1, 2))
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	a.Observers = []Observer{NewSlogObserver(logger, 1, slog.LevelDebug)}
+	setup, _ := factory(3)
+	if _, err := a.Iterate(setup.InitialSolution, setup.Selector, setup.Acceptor, setup.Stop); err == nil {
+		t.Fatal("an error expected")
+	}
+
+	records := readLogRecords(t, &buf)
+	if len(records) != 2 {
+		t.Fatalf("the start and the stop records expected, actual %v", records)
+	}
+	if stop := records[1]; stop["level"] != "ERROR" || stop["reason"] != "error" || stop["error"] != "broken" {
+		t.Fatalf("the stop record of the error expected, actual %v", stop)
+	}
+}
@@ -5,16 +5,29 @@ import (
 	"time"
 )
 
+// Statistics describes a run. The iterations are counted from one, i.e. the iteration n is the n-th
+// iteration of the run and the iteration zero is the initial solution. The same numbering is used by
+// BestIteration, RestartEvent, DestructionEvent, IterationEvent, TraceRecord and Replay.
 type Statistics struct {
-	IterationCount        int                  // the number of iterations
-	TotalRuntime          time.Duration        // the total runtime
-	Runtimes              []time.Duration      // run times
-	Objectives            []float64            // previous objective values, tracking progress
-	DestroyOperatorCounts []OperatorStatistics // the destroy operator counts
-	RepairOperatorCounts  []OperatorStatistics // the repair operator counts
+	IterationCount        int                     // the number of iterations
+	TotalRuntime          time.Duration           // the total runtime
+	BestIteration         int                     // the iteration in which the best solution was found, zero for the initial solution
+	BestRuntime           time.Duration           // the time at which the best solution was found
+	InfeasibleCount       int                     // the number of infeasible candidates
+	DeltaMismatches       int                     // the number of corrected objective deltas, see DeltaEvaluated
+	DeltaMismatchCounts   map[string]int          // the number of corrected objective deltas per operator name
+	Runtimes              []time.Duration         // run times
+	Objectives            []float64               // previous objective values, tracking progress
+	DestroyOperatorCounts []OperatorStatistics    // the destroy operator counts
+	RepairOperatorCounts  []OperatorStatistics    // the repair operator counts
+	DestroyDuplicates     []int                   // the number of duplicate candidates per destroy operator
+	RepairDuplicates      []int                   // the number of duplicate candidates per repair operator
+	LocalSearches         []LocalSearchStatistics // the local search statistics
+	Restarts              []RestartEvent          // the restarts of the current solution
+	Destructions          []DestructionEvent      // the degrees of destruction, see ALNS.Destruction and ALNS.CollectObjectives
 }
 
-func newStatistics(numIterations int, numDestroy, numRepair int) Statistics {
+func newStatistics(numIterations int, numDestroy, numRepair, numLocalSearch int) Statistics {
 	var runtimes []time.Duration
 	var objectives []float64
 	if numIterations > 0 {
@@ -26,6 +39,9 @@ func newStatistics(numIterations int, numDestroy, numRepair int) Statistics {
 		Objectives:            objectives,
 		DestroyOperatorCounts: make([]OperatorStatistics, numDestroy),
 		RepairOperatorCounts:  make([]OperatorStatistics, numRepair),
+		DestroyDuplicates:     make([]int, numDestroy),
+		RepairDuplicates:      make([]int, numRepair),
+		LocalSearches:         make([]LocalSearchStatistics, numLocalSearch),
 	}
 }
 
@@ -34,6 +50,51 @@ func (s *Statistics) collectObjective(t time.Duration, objective float64) {
 	s.Objectives = append(s.Objectives, objective)
 }
 
+func (s *Statistics) collectBest(t time.Duration) {
+	s.BestIteration = s.IterationCount
+	s.BestRuntime = t
+}
+
+func (s *Statistics) collectRestart(t time.Duration, from, to float64) {
+	s.Restarts = append(s.Restarts, RestartEvent{
+		Iteration: s.IterationCount,
+		Runtime:   t,
+		From:      from,
+		To:        to,
+	})
+}
+
+func (s *Statistics) collectDestruction(degree float64, outcome Outcome) {
+	s.Destructions = append(s.Destructions, DestructionEvent{
+		Iteration: s.IterationCount,
+		Degree:    degree,
+		Outcome:   outcome,
+	})
+}
+
+func (s *Statistics) collectDeltaMismatch(operator string) {
+	s.DeltaMismatches++
+	if s.DeltaMismatchCounts == nil {
+		s.DeltaMismatchCounts = make(map[string]int)
+	}
+	s.DeltaMismatchCounts[operator]++
+}
+
+func (s *Statistics) collectDuplicate(dIdx, rIdx int) {
+	s.DestroyDuplicates[dIdx]++
+	s.RepairDuplicates[rIdx]++
+}
+
+func (s *Statistics) collectLocalSearch(idx int, t time.Duration, improvement float64) {
+	l := &s.LocalSearches[idx]
+	l.Calls++
+	l.Runtime += t
+	if improvement > 0 {
+		l.Improvements++
+		l.Improvement += improvement
+	}
+}
+
 func (s *Statistics) collectOperators(dIdx, rIdx int, outcome Outcome) {
 	s.DestroyOperatorCounts[dIdx][outcome]++
 	s.RepairOperatorCounts[rIdx][outcome]++
@@ -50,3 +111,16 @@ func (o OperatorStatistics) String() string {
 		Reject, o[Reject],
 	)
 }
+
+type RestartEvent struct {
+	Iteration int           // the iteration after which the restart happened, see Statistics
+	Runtime   time.Duration // the time since the start
+	From      float64       // the objective of the current solution before the restart
+	To        float64       // the objective of the current solution after the restart
+}
+
+type DestructionEvent struct {
+	Iteration int     // the iteration, see Statistics
+	Degree    float64 // the degree of destruction passed to the destroy operator
+	Outcome   Outcome // the outcome of the iteration
+}
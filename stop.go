@@ -53,7 +53,7 @@ type NoImprovement struct {
 	MaxIterations int
 	counter       int
 	isInitialized bool
-	target        float64
+	target        State
 }
 
 var _ StoppingCriterion = &NoImprovement{}
@@ -65,9 +65,9 @@ func NewNoImprovement(maxIterations int) NoImprovement {
 }
 
 func (s *NoImprovement) IsDone(rnd *rand.Rand, best, current State) (bool, error) {
-	if !s.isInitialized || best.Objective() < s.target {
+	if !s.isInitialized || compareStates(best, s.target) < 0 {
 		s.isInitialized = true
-		s.target = best.Objective()
+		s.target = best
 		s.counter = 0
 	} else {
 		s.counter++
@@ -97,6 +97,23 @@ func (s StoppingCriterions) IsDone(rnd *rand.Rand, best, current State) (bool, e
 	return false, nil
 }
 
+// firedCriterion returns the stopping criterion that is done or nil, the criterions of StoppingCriterions
+// are checked in order.
+func firedCriterion(stop StoppingCriterion, rnd *rand.Rand, best, current State) (StoppingCriterion, error) {
+	if criterions, ok := stop.(StoppingCriterions); ok && len(criterions) > 0 {
+		for _, c := range criterions {
+			if fired, err := firedCriterion(c, rnd, best, current); err != nil || fired != nil {
+				return fired, err
+			}
+		}
+		return nil, nil
+	}
+	if done, err := stop.IsDone(rnd, best, current); err != nil || !done {
+		return nil, err
+	}
+	return stop, nil
+}
+
 type Context struct {
 	Context context.Context
 }
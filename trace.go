@@ -0,0 +1,105 @@
+package alns
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// TraceRecord is a line of the trace written by TraceRecorder.
+type TraceRecord struct {
+	Iteration int               `json:"iteration"` // see Statistics
+	Destroy   int               `json:"destroy"`
+	Repair    int               `json:"repair"`
+	Candidate float64           `json:"candidate"`
+	Current   float64           `json:"current"`
+	Best      float64           `json:"best"`
+	Outcome   Outcome           `json:"outcome"`
+	Accepted  bool              `json:"accepted"`
+	Random    map[string]string `json:"random,omitempty"` // see IterationEvent.RandomState
+}
+
+// The `TraceRecorder` is an Observer that writes every iteration as a JSON line, see TraceRecord.
+// The writer is not buffered by the recorder.
+type TraceRecorder struct {
+	encoder *json.Encoder
+}
+
+var _ Observer = &TraceRecorder{}
+
+func NewTraceRecorder(w io.Writer) *TraceRecorder {
+	return &TraceRecorder{
+		encoder: json.NewEncoder(w),
+	}
+}
+
+func (r *TraceRecorder) OnIteration(event *IterationEvent) error {
+	return r.encoder.Encode(TraceRecord{
+		Iteration: event.Iteration,
+		Destroy:   event.Destroy,
+		Repair:    event.Repair,
+		Candidate: event.Candidate.Objective(),
+		Current:   event.Current.Objective(),
+		Best:      event.Best.Objective(),
+		Outcome:   event.Outcome,
+		Accepted:  event.Accepted,
+		Random:    event.RandomState(),
+	})
+}
+
+// ReadTrace reads the trace written by TraceRecorder.
+func ReadTrace(r io.Reader) ([]TraceRecord, error) {
+	var records []TraceRecord
+	decoder := json.NewDecoder(r)
+	for {
+		var record TraceRecord
+		if err := decoder.Decode(&record); err == io.EOF {
+			return records, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("trace record %d: %w", len(records), err)
+		}
+		records = append(records, record)
+	}
+}
+
+// Replay re-executes the run of the seed (see Result.Seed) and returns the current and the best solution
+// after the given number of iterations, zero gives the initial solution. The factory must create the same
+// components as for the original run and the run must not depend on the time (e.g. MaxRuntime);
+// as in MultiStart, the restart strategy and the degree of destruction must be created by the factory.
+// The run uses fresh copies of the stateful components of `a` and does not notify its observers.
+func Replay(a ALNS, factory RunFactory, seed uint64, iteration int) (curr, best State, err error) {
+	if iteration < 0 {
+		return nil, nil, fmt.Errorf("negative iteration not understood")
+	}
+	if err := checkShared(&a); err != nil {
+		return nil, nil, err
+	}
+	setup, err := factory(seed)
+	if err != nil {
+		return nil, nil, err
+	}
+	a = seeded(a, setup, seed)
+	last := lastIteration{curr: setup.InitialSolution, best: setup.InitialSolution}
+	a.Observers = []Observer{&last}
+	stop := NewStoppingCriterions(&MaxIterations{MaxIterations: iteration}, setup.Stop)
+	if _, err := a.Iterate(setup.InitialSolution, setup.Selector, setup.Acceptor, stop); err != nil {
+		return nil, nil, err
+	}
+	if last.count < iteration {
+		return nil, nil, fmt.Errorf("the run stopped after %d iterations", last.count)
+	}
+	return last.curr, last.best, nil
+}
+
+// lastIteration keeps the solutions of the last iteration.
+type lastIteration struct {
+	count int
+	curr  State
+	best  State
+}
+
+func (l *lastIteration) OnIteration(event *IterationEvent) error {
+	l.count++
+	l.curr, l.best = event.Current, event.Best
+	return nil
+}
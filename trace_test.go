@@ -0,0 +1,180 @@
+package alns
+
+import (
+	"bytes"
+	"math/rand/v2"
+	"testing"
+)
+
+func traceFixture() (ALNS, RunFactory) {
+	a := ALNS{
+		DestroyOperators: []Operator{
+			func(state State, rnd *rand.Rand) (State, error) { return state.(*FakeState).Clone(), nil },
+			func(state State, rnd *rand.Rand) (State, error) { return state.(*FakeState).Clone(), nil },
+		},
+		RepairOperators: []Operator{
+			func(state State, rnd *rand.Rand) (State, error) {
+				state.(*FakeState).objective = rnd.Float64()
+				return state, nil
+			},
+		},
+	}
+	factory := func(seed uint64) (RunSetup, error) {
+		selector, err := NewRouletteWheel([4]float64{3, 2, 1, 0.5}, 0.8, 2, 1, nil)
+		if err != nil {
+			return RunSetup{}, err
+		}
+		return RunSetup{
+			InitialSolution: &FakeState{objective: 1},
+			Selector:        &selector,
+			Acceptor:        &AcceptAll{},
+			Stop:            &MaxIterations{MaxIterations: 50},
+		}, nil
+	}
+	return a, factory
+}
+
+func TestTraceRecorder(t *testing.T) {
+	a, factory := traceFixture()
+	var buf bytes.Buffer
+	a.Observers = []Observer{NewTraceRecorder(&buf)}
+	setup, _ := factory(3)
+	seeds := NewSeedSequence(3)
+	a.Seeds = &seeds
+	result, err := a.Iterate(setup.InitialSolution, setup.Selector, setup.Acceptor, setup.Stop)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := ReadTrace(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 50 {
+		t.Fatalf("50 records expected, actual %d", len(records))
+	}
+	counts := result.Statistics.DestroyOperatorCounts
+	bestIteration := 0
+	for i, record := range records {
+		if record.Iteration != i+1 {
+			t.Fatalf("the iteration %d expected, actual %d", i+1, record.Iteration)
+		}
+		if record.Outcome == Best {
+			bestIteration = record.Iteration
+		}
+		counts[record.Destroy][record.Outcome]--
+		if !record.Accepted {
+			t.Fatal("AcceptAll is expected to accept every candidate")
+		}
+		for _, name := range []string{"select", "accept", "stop", "engine", "repair/0"} {
+			if record.Random[name] == "" {
+				t.Fatalf("the state of the stream %s expected in %v", name, record.Random)
+			}
+		}
+	}
+	for _, count := range counts {
+		if count != (OperatorStatistics{}) {
+			t.Fatalf("the records do not match the operator statistics %v", counts)
+		}
+	}
+	if bestIteration != result.Statistics.BestIteration {
+		t.Fatalf("the best iteration %d expected, actual %d", result.Statistics.BestIteration, bestIteration)
+	}
+	if last := records[len(records)-1]; last.Best != result.BestState.Objective() {
+		t.Fatalf("the best %f expected in the last record, actual %f", result.BestState.Objective(), last.Best)
+	}
+}
+
+func TestReplay(t *testing.T) {
+	a, factory := traceFixture()
+	var buf bytes.Buffer
+	traced := a
+	traced.Observers = []Observer{NewTraceRecorder(&buf)}
+	if _, err := MultiStart(traced, factory, []uint64{5}, 1); err != nil {
+		t.Fatal(err)
+	}
+	records, err := ReadTrace(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, iteration := range []int{0, 1, 17, 50} {
+		curr, best, err := Replay(traced, factory, 5, iteration)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expectedCurr, expectedBest := 1.0, 1.0
+		if iteration > 0 {
+			expectedCurr, expectedBest = records[iteration-1].Current, records[iteration-1].Best
+		}
+		if curr.Objective() != expectedCurr || best.Objective() != expectedBest {
+			t.Fatalf("iteration %d: the current %f and the best %f expected, actual %f and %f",
+				iteration, expectedCurr, expectedBest, curr.Objective(), best.Objective())
+		}
+	}
+	if buf.Len() != 0 {
+		t.Fatal("the replay is not expected to notify the observers of the run")
+	}
+	if _, _, err := Replay(a, factory, 5, 51); err == nil {
+		t.Fatal("an error expected for an iteration after the end of the run")
+	}
+}
+
+func TestReplayPenalty(t *testing.T) {
+	penalty, err := NewAdaptivePenalty(1, 0.1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	seeds := NewSeedSequence(5)
+	var buf bytes.Buffer
+	a := ALNS{
+		DestroyOperators: []Operator{
+			func(state State, rnd *rand.Rand) (State, error) { return &ConstrainedState{}, nil },
+		},
+		RepairOperators: []Operator{
+			func(state State, rnd *rand.Rand) (State, error) {
+				cand := state.(*ConstrainedState)
+				cand.objective, cand.violation = rnd.Float64(), float64(rnd.IntN(2))
+				return cand, nil
+			},
+		},
+		Penalty:   &penalty,
+		Seeds:     &seeds,
+		Observers: []Observer{NewTraceRecorder(&buf)},
+	}
+	factory := func(seed uint64) (RunSetup, error) {
+		selector, err := NewRouletteWheel([4]float64{3, 2, 1, 0.5}, 0.8, 1, 1, nil)
+		if err != nil {
+			return RunSetup{}, err
+		}
+		return RunSetup{
+			InitialSolution: &ConstrainedState{objective: 1},
+			Selector:        &selector,
+			Acceptor:        &HillClimbing{},
+			Stop:            &MaxIterations{MaxIterations: 50},
+		}, nil
+	}
+	setup, _ := factory(5)
+	if _, err := a.Iterate(setup.InitialSolution, setup.Selector, setup.Acceptor, setup.Stop); err != nil {
+		t.Fatal(err)
+	}
+	if penalty.Weight == 1 {
+		t.Fatal("the penalty weight was not adjusted")
+	}
+	records, err := ReadTrace(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the replay starts from the initial weight, not from the weight adjusted by the run
+	for iteration := 1; iteration <= 50; iteration++ {
+		curr, _, err := Replay(a, factory, 5, iteration)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if curr.Objective() != records[iteration-1].Current {
+			t.Fatalf("iteration %d: the current solution %f expected, actual %f",
+				iteration, records[iteration-1].Current, curr.Objective())
+		}
+	}
+}
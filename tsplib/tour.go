@@ -0,0 +1,103 @@
+package tsplib
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+)
+
+// Tour is a TSPLIB tour, the nodes are numbered from 0.
+type Tour struct {
+	Name    string
+	Comment string
+	Nodes   []int
+}
+
+// ReadTourFile reads a tour file, e.g. an .opt.tour file.
+func ReadTourFile(name string) (*Tour, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ParseTour(f)
+}
+
+// ParseTour parses a tour.
+func ParseTour(r io.Reader) (*Tour, error) {
+	p, err := ParseSections(r)
+	if err != nil {
+		return nil, err
+	}
+	if p.Specification["TYPE"] != "TOUR" {
+		return nil, fmt.Errorf("unsupported type %q", p.Specification["TYPE"])
+	}
+	dimension, err := p.dimension()
+	if err != nil {
+		return nil, err
+	}
+	tokens, ok := p.Data["TOUR_SECTION"]
+	if !ok {
+		return nil, fmt.Errorf("missing TOUR_SECTION")
+	}
+
+	tour := Tour{
+		Name:    p.Specification["NAME"],
+		Comment: p.Specification["COMMENT"],
+		Nodes:   make([]int, 0, dimension),
+	}
+	seen := make([]bool, dimension)
+	for _, token := range tokens {
+		node, err := strconv.Atoi(token)
+		if err != nil {
+			return nil, err
+		}
+		if node == -1 {
+			break
+		}
+		if node < 1 || node > dimension || seen[node-1] {
+			return nil, fmt.Errorf("invalid node %d", node)
+		}
+		seen[node-1] = true
+		tour.Nodes = append(tour.Nodes, node-1)
+	}
+	if len(tour.Nodes) != dimension {
+		return nil, fmt.Errorf("%d nodes expected, actual %d", dimension, len(tour.Nodes))
+	}
+	return &tour, nil
+}
+
+// WriteTourFile writes the tour to a file.
+func WriteTourFile(name string, tour *Tour) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if err := WriteTour(f, tour); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// WriteTour writes the tour in the TSPLIB format.
+func WriteTour(w io.Writer, tour *Tour) error {
+	bw := bufio.NewWriter(w)
+	if tour.Name != "" {
+		fmt.Fprintf(bw, "NAME : %s\n", tour.Name)
+	}
+	if tour.Comment != "" {
+		fmt.Fprintf(bw, "COMMENT : %s\n", tour.Comment)
+	}
+	fmt.Fprintln(bw, "TYPE : TOUR")
+	fmt.Fprintf(bw, "DIMENSION : %d\n", len(tour.Nodes))
+	fmt.Fprintln(bw, "TOUR_SECTION")
+	for _, node := range tour.Nodes {
+		fmt.Fprintln(bw, node+1)
+	}
+	fmt.Fprintln(bw, "-1")
+	fmt.Fprintln(bw, "EOF")
+	return bw.Flush()
+}
@@ -0,0 +1,293 @@
+// Package tsplib reads TSPLIB instances (.tsp) and tours (.tour, .opt.tour) and writes tours.
+//
+// The nodes are numbered from 1 in the files and from 0 in this package.
+package tsplib
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Instance is a symmetric TSP instance.
+type Instance struct {
+	Name             string
+	Comment          string
+	Type             string // TSP
+	Dimension        int
+	EdgeWeightType   string       // EUC_2D, CEIL_2D, ATT, GEO or EXPLICIT
+	EdgeWeightFormat string       // FULL_MATRIX, UPPER_ROW, UPPER_DIAG_ROW or LOWER_DIAG_ROW for EXPLICIT
+	Coords           [][2]float64 // the node coordinates, empty for EXPLICIT
+	Weights          [][]float64  // the full distance matrix, only for EXPLICIT
+}
+
+// ReadFile reads an instance file.
+func ReadFile(name string) (*Instance, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Parse(f)
+}
+
+// Parse parses an instance.
+func Parse(r io.Reader) (*Instance, error) {
+	p, err := ParseSections(r)
+	if err != nil {
+		return nil, err
+	}
+
+	instance := Instance{
+		Name:             p.Specification["NAME"],
+		Comment:          p.Specification["COMMENT"],
+		Type:             p.Specification["TYPE"],
+		EdgeWeightType:   p.Specification["EDGE_WEIGHT_TYPE"],
+		EdgeWeightFormat: p.Specification["EDGE_WEIGHT_FORMAT"],
+	}
+	if instance.Type != "TSP" {
+		return nil, fmt.Errorf("unsupported type %q", instance.Type)
+	}
+	if instance.Dimension, err = p.dimension(); err != nil {
+		return nil, err
+	}
+
+	switch instance.EdgeWeightType {
+	case "EUC_2D", "CEIL_2D", "ATT", "GEO":
+		if instance.Coords, err = parseCoords(p.Data["NODE_COORD_SECTION"], instance.Dimension); err != nil {
+			return nil, err
+		}
+	case "EXPLICIT":
+		instance.Weights, err = parseWeights(p.Data["EDGE_WEIGHT_SECTION"], instance.Dimension, instance.EdgeWeightFormat)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported edge weight type %q", instance.EdgeWeightType)
+	}
+	return &instance, nil
+}
+
+func parseCoords(tokens []string, dimension int) ([][2]float64, error) {
+	if tokens == nil {
+		return nil, fmt.Errorf("missing NODE_COORD_SECTION")
+	}
+	if len(tokens) != 3*dimension {
+		return nil, fmt.Errorf("%d node coordinates expected, actual %d values", dimension, len(tokens))
+	}
+	values, err := parseFloats(tokens)
+	if err != nil {
+		return nil, err
+	}
+	coords := make([][2]float64, dimension)
+	seen := make([]bool, dimension)
+	for i := range dimension {
+		node := int(values[3*i]) - 1
+		if node < 0 || node >= dimension || seen[node] {
+			return nil, fmt.Errorf("invalid node %v", values[3*i])
+		}
+		seen[node] = true
+		coords[node] = [2]float64{values[3*i+1], values[3*i+2]}
+	}
+	return coords, nil
+}
+
+func parseWeights(tokens []string, dimension int, format string) ([][]float64, error) {
+	if tokens == nil {
+		return nil, fmt.Errorf("missing EDGE_WEIGHT_SECTION")
+	}
+	values, err := parseFloats(tokens)
+	if err != nil {
+		return nil, err
+	}
+
+	var expected int
+	switch format {
+	case "FULL_MATRIX":
+		expected = dimension * dimension
+	case "UPPER_ROW":
+		expected = dimension * (dimension - 1) / 2
+	case "UPPER_DIAG_ROW", "LOWER_DIAG_ROW":
+		expected = dimension * (dimension + 1) / 2
+	default:
+		return nil, fmt.Errorf("unsupported edge weight format %q", format)
+	}
+	if len(values) != expected {
+		return nil, fmt.Errorf("%d edge weights expected for %s, actual %d", expected, format, len(values))
+	}
+
+	weights := make([][]float64, dimension)
+	for i := range weights {
+		weights[i] = make([]float64, dimension)
+	}
+	k := 0
+	for i := range dimension {
+		switch format {
+		case "FULL_MATRIX":
+			copy(weights[i], values[k:k+dimension])
+			k += dimension
+		case "UPPER_ROW":
+			for j := i + 1; j < dimension; j++ {
+				weights[i][j], weights[j][i] = values[k], values[k]
+				k++
+			}
+		case "UPPER_DIAG_ROW":
+			for j := i; j < dimension; j++ {
+				weights[i][j], weights[j][i] = values[k], values[k]
+				k++
+			}
+		case "LOWER_DIAG_ROW":
+			for j := 0; j <= i; j++ {
+				weights[i][j], weights[j][i] = values[k], values[k]
+				k++
+			}
+		}
+	}
+	return weights, nil
+}
+
+// Distance returns the distance between the nodes i and j as defined by TSPLIB.
+func (inst *Instance) Distance(i, j int) float64 {
+	if inst.Weights != nil {
+		return inst.Weights[i][j]
+	}
+	a, b := inst.Coords[i], inst.Coords[j]
+	switch inst.EdgeWeightType {
+	case "CEIL_2D":
+		return math.Ceil(math.Hypot(a[0]-b[0], a[1]-b[1]))
+	case "ATT":
+		dx, dy := a[0]-b[0], a[1]-b[1]
+		r := math.Sqrt((dx*dx + dy*dy) / 10)
+		t := nint(r)
+		if t < r {
+			return t + 1
+		}
+		return t
+	case "GEO":
+		if i == j {
+			return 0
+		}
+		latA, lonA := geo(a[0]), geo(a[1])
+		latB, lonB := geo(b[0]), geo(b[1])
+		const rrr = 6378.388
+		q1 := math.Cos(lonA - lonB)
+		q2 := math.Cos(latA - latB)
+		q3 := math.Cos(latA + latB)
+		return math.Trunc(rrr*math.Acos(0.5*((1+q1)*q2-(1-q1)*q3)) + 1)
+	default:
+		return nint(math.Hypot(a[0]-b[0], a[1]-b[1]))
+	}
+}
+
+// Distances returns the full distance matrix.
+func (inst *Instance) Distances() [][]float64 {
+	dists := make([][]float64, inst.Dimension)
+	for i := range dists {
+		dists[i] = make([]float64, inst.Dimension)
+		for j := range dists[i] {
+			dists[i][j] = inst.Distance(i, j)
+		}
+	}
+	return dists
+}
+
+// TourLength returns the length of the closed tour.
+func (inst *Instance) TourLength(tour []int) float64 {
+	length := 0.0
+	for i, node := range tour {
+		length += inst.Distance(node, tour[(i+1)%len(tour)])
+	}
+	return length
+}
+
+func nint(x float64) float64 {
+	return math.Floor(x + 0.5)
+}
+
+// geo converts DDD.MM (degrees and minutes) to radians.
+func geo(x float64) float64 {
+	const pi = 3.141592
+	deg := math.Trunc(x)
+	minutes := x - deg
+	return pi * (deg + 5*minutes/3) / 180
+}
+
+// Sections is a TSPLIB file split into the specification entries and the data sections,
+// the format is shared by VRPLIB (see ParseSections).
+type Sections struct {
+	Specification map[string]string   // the specification entries "KEY : value"
+	Data          map[string][]string // the whitespace separated tokens of the data sections by the section name
+}
+
+// ParseSections reads the specification entries and the data sections up to EOF, the keywords
+// start with an uppercase letter.
+func ParseSections(r io.Reader) (*Sections, error) {
+	p := Sections{
+		Specification: map[string]string{},
+		Data:          map[string][]string{},
+	}
+	section := ""
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if line == "EOF" {
+			break
+		}
+		if isKeyword(line) {
+			key, value, found := strings.Cut(line, ":")
+			key = strings.TrimSpace(key)
+			if found {
+				p.Specification[key] = strings.TrimSpace(value)
+				section = ""
+			} else {
+				section = key
+				p.Data[section] = []string{}
+			}
+			continue
+		}
+		if section == "" {
+			return nil, fmt.Errorf("unexpected line %q", line)
+		}
+		p.Data[section] = append(p.Data[section], strings.Fields(line)...)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func isKeyword(line string) bool {
+	c := line[0]
+	return 'A' <= c && c <= 'Z'
+}
+
+func (p *Sections) dimension() (int, error) {
+	dimension, err := strconv.Atoi(p.Specification["DIMENSION"])
+	if err != nil {
+		return 0, fmt.Errorf("invalid DIMENSION: %w", err)
+	}
+	if dimension <= 0 {
+		return 0, fmt.Errorf("invalid DIMENSION %d", dimension)
+	}
+	return dimension, nil
+}
+
+func parseFloats(tokens []string) ([]float64, error) {
+	values := make([]float64, len(tokens))
+	for i, token := range tokens {
+		value, err := strconv.ParseFloat(token, 64)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = value
+	}
+	return values, nil
+}
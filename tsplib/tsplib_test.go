@@ -0,0 +1,238 @@
+package tsplib
+
+import (
+	"bytes"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+)
+
+const euc2d = `NAME : euc8
+COMMENT : 8 random nodes
+TYPE : TSP
+DIMENSION : 8
+EDGE_WEIGHT_TYPE : EUC_2D
+NODE_COORD_SECTION
+1 41 19
+2 50 83
+3 6 9
+4 68 12
+5 46 74
+6 7 64
+7 27 4
+8 11 55
+EOF
+`
+
+const att = `NAME : att8
+TYPE : TSP
+DIMENSION : 8
+EDGE_WEIGHT_TYPE : ATT
+NODE_COORD_SECTION
+1 3425 572
+2 1971 743
+3 4514 3477
+4 484 4632
+5 1014 1828
+6 4775 506
+7 4727 4796
+8 3249 406
+EOF
+`
+
+const burma14 = `NAME: burma14
+TYPE: TSP
+COMMENT: 14-Staedte in Burma (Zaw Win)
+DIMENSION: 14
+EDGE_WEIGHT_TYPE: GEO
+EDGE_WEIGHT_FORMAT: FUNCTION
+DISPLAY_DATA_TYPE: COORD_DISPLAY
+NODE_COORD_SECTION
+   1  16.47       96.10
+   2  16.47       94.44
+   3  20.09       92.54
+   4  22.39       93.37
+   5  25.23       97.24
+   6  22.00       96.05
+   7  20.47       97.02
+   8  17.20       96.29
+   9  16.30       97.38
+  10  14.05       98.12
+  11  16.53       97.38
+  12  21.52       95.59
+  13  19.41       97.13
+  14  20.09       94.55
+EOF
+`
+
+const burma14OptTour = `NAME : burma14.opt.tour
+COMMENT : Optimal tour for burma14 (3323)
+TYPE : TOUR
+DIMENSION : 14
+TOUR_SECTION
+1
+2
+14
+3
+4
+5
+6
+12
+7
+13
+8
+11
+9
+10
+-1
+EOF
+`
+
+const fullMatrix = `NAME : full6
+TYPE : TSP
+DIMENSION : 6
+EDGE_WEIGHT_TYPE : EXPLICIT
+EDGE_WEIGHT_FORMAT : FULL_MATRIX
+EDGE_WEIGHT_SECTION
+ 0 15  3 36  9 19
+15  0 27 10 35  8
+ 3 27  0 37 20 36
+36 10 37  0 44 12
+ 9 35 20 44  0  7
+19  8 36 12  7  0
+EOF
+`
+
+const upperRow = `NAME : upper6
+TYPE : TSP
+DIMENSION : 6
+EDGE_WEIGHT_TYPE : EXPLICIT
+EDGE_WEIGHT_FORMAT : UPPER_ROW
+EDGE_WEIGHT_SECTION
+15  3 36  9 19
+27 10 35  8
+37 20 36
+44 12
+7
+EOF
+`
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		optimal  float64
+		optTour  []int
+		override string // the edge weight type
+	}{
+		{"EUC_2D", euc2d, 250, []int{0, 3, 1, 4, 5, 7, 2, 6}, ""},
+		{"CEIL_2D", euc2d, 252, []int{0, 3, 4, 1, 5, 7, 2, 6}, "CEIL_2D"},
+		{"ATT", att, 4994, []int{0, 5, 2, 6, 3, 4, 1, 7}, ""},
+		{"GEO", burma14, 3323, []int{0, 1, 13, 2, 3, 4, 5, 11, 6, 12, 7, 10, 8, 9}, ""},
+		{"FULL_MATRIX", fullMatrix, 67, []int{0, 1, 3, 5, 4, 2}, ""},
+		{"UPPER_ROW", upperRow, 67, []int{0, 1, 3, 5, 4, 2}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := tt.data
+			if tt.override != "" {
+				data = strings.Replace(data, "EUC_2D", tt.override, 1)
+			}
+			instance, err := Parse(strings.NewReader(data))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if instance.Dimension != len(tt.optTour) {
+				t.Fatalf("dimension %d expected, actual %d", len(tt.optTour), instance.Dimension)
+			}
+			if got := instance.TourLength(tt.optTour); got != tt.optimal {
+				t.Fatalf("the optimal tour length %f expected, actual %f", tt.optimal, got)
+			}
+			if instance.Dimension <= 8 {
+				if got := shortestTour(instance); got != tt.optimal {
+					t.Fatalf("the shortest tour %f expected, actual %f", tt.optimal, got)
+				}
+			}
+		})
+	}
+}
+
+// shortestTour finds the optimal tour length by enumerating all tours starting at node 0.
+func shortestTour(instance *Instance) float64 {
+	best := math.Inf(1)
+	tour := make([]int, instance.Dimension)
+	for i := range tour {
+		tour[i] = i
+	}
+	var permute func(k int)
+	permute = func(k int) {
+		if k == len(tour) {
+			best = min(best, instance.TourLength(tour))
+			return
+		}
+		for i := k; i < len(tour); i++ {
+			tour[k], tour[i] = tour[i], tour[k]
+			permute(k + 1)
+			tour[k], tour[i] = tour[i], tour[k]
+		}
+	}
+	permute(1)
+	return best
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		data string
+		want string
+	}{
+		{strings.Replace(euc2d, "TYPE : TSP", "TYPE : CVRP", 1), `unsupported type "CVRP"`},
+		{strings.Replace(euc2d, "EUC_2D", "EUC_3D", 1), `unsupported edge weight type "EUC_3D"`},
+		{strings.Replace(euc2d, "DIMENSION : 8", "DIMENSION : 9", 1), "9 node coordinates expected, actual 24 values"},
+		{strings.Replace(euc2d, "8 11 55", "7 11 55", 1), "invalid node 7"},
+		{strings.Replace(upperRow, "\n7\n", "\n", 1), "15 edge weights expected for UPPER_ROW, actual 14"},
+	}
+	for i, tt := range tests {
+		_, err := Parse(strings.NewReader(tt.data))
+		if err == nil || err.Error() != tt.want {
+			t.Errorf("test %d: error %q expected, actual %v", i, tt.want, err)
+		}
+	}
+}
+
+func TestTour(t *testing.T) {
+	instance, err := Parse(strings.NewReader(burma14))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tour, err := ParseTour(strings.NewReader(burma14OptTour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tour.Name != "burma14.opt.tour" {
+		t.Fatalf("unexpected name %q", tour.Name)
+	}
+	if got := instance.TourLength(tour.Nodes); got != 3323 {
+		t.Fatalf("the tour length 3323 expected, actual %f", got)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteTour(&buf, tour); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != burma14OptTour {
+		t.Fatalf("unexpected tour file:\n%s", buf.String())
+	}
+	written, err := ParseTour(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(written.Nodes, tour.Nodes) {
+		t.Fatalf("tour %v expected, actual %v", tour.Nodes, written.Nodes)
+	}
+
+	_, err = ParseTour(strings.NewReader(strings.Replace(burma14OptTour, "\n13\n", "\n12\n", 1)))
+	if err == nil || err.Error() != "invalid node 12" {
+		t.Fatalf("duplicate node error expected, actual %v", err)
+	}
+}
@@ -1,6 +1,7 @@
 package alns
 
 import (
+	"fmt"
 	"math/rand/v2"
 )
 
@@ -30,3 +31,21 @@ func sum(weights []float64) float64 {
 	}
 	return sum
 }
+
+// validationError is returned by the validation of components, it keeps the name of the invalid field
+// so that the configuration loader can report the full path of the field.
+type validationError struct {
+	field string
+	msg   string
+}
+
+func newValidationError(field string, format string, args ...any) error {
+	return &validationError{
+		field: field,
+		msg:   fmt.Sprintf(format, args...),
+	}
+}
+
+func (e *validationError) Error() string {
+	return e.msg
+}